@@ -0,0 +1,59 @@
+package psref
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// adaptiveRateSteps is the number of successful requests needed to recover from the minimal rate to the maximal one.
+const adaptiveRateSteps = 20
+
+// adaptiveRate adjusts the rate limiter depending on the response status. See WithAdaptiveRate.
+//
+// It uses additive increase and multiplicative decrease (AIMD): the rate is halved each time the server
+// throttles the client, and is increased by a fixed step on each successful request.
+type adaptiveRate struct {
+	min, max rate.Limit
+	lim      *rate.Limiter
+
+	mu sync.Mutex
+}
+
+// newAdaptiveRate creates an adaptive rate limiter, adjusting invalid bounds. See WithAdaptiveRate.
+func newAdaptiveRate(min, max rate.Limit) *adaptiveRate {
+	if max <= 0 || max == rate.Inf {
+		max = rate.Every(apiDefaultRateInterval)
+	}
+	if min <= 0 {
+		min = max / adaptiveRateSteps
+	} else if min > max {
+		min = max
+	}
+	return &adaptiveRate{min: min, max: max, lim: rate.NewLimiter(max, 1)}
+}
+
+// observe adjusts the rate after receiving a response with a given status code.
+func (a *adaptiveRate) observe(status int) {
+	var dec bool
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		dec = true
+	case http.StatusOK, http.StatusNotFound, http.StatusNotModified:
+	default:
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cur := a.lim.Limit()
+	if dec {
+		cur /= 2
+	} else {
+		cur += (a.max - a.min) / adaptiveRateSteps
+	}
+	cur = max(a.min, min(a.max, cur))
+	if cur != a.lim.Limit() {
+		a.lim.SetLimit(cur)
+	}
+}
@@ -0,0 +1,93 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestAdaptiveRateObserve(t *testing.T) {
+	a := newAdaptiveRate(10, 100)
+	require.Equal(t, rate.Limit(100), a.lim.Limit())
+	a.observe(http.StatusTooManyRequests)
+	require.Equal(t, rate.Limit(50), a.lim.Limit())
+	a.observe(http.StatusServiceUnavailable)
+	a.observe(http.StatusServiceUnavailable)
+	a.observe(http.StatusServiceUnavailable)
+	require.Equal(t, rate.Limit(10), a.lim.Limit())
+	a.observe(http.StatusInternalServerError)
+	require.Equal(t, rate.Limit(10), a.lim.Limit())
+	a.observe(http.StatusOK)
+	require.Equal(t, rate.Limit(14.5), a.lim.Limit())
+	for i := 0; i < adaptiveRateSteps; i++ {
+		a.observe(http.StatusOK)
+	}
+	require.Equal(t, rate.Limit(100), a.lim.Limit())
+}
+
+func TestAdaptiveRateBounds(t *testing.T) {
+	def := rate.Every(apiDefaultRateInterval)
+	for _, c := range []struct {
+		name     string
+		min, max rate.Limit
+		expMin   rate.Limit
+		expMax   rate.Limit
+	}{
+		{name: "valid", min: 1, max: 10, expMin: 1, expMax: 10},
+		{name: "zero min", min: 0, max: 20, expMin: 1, expMax: 20},
+		{name: "negative min", min: -1, max: 20, expMin: 1, expMax: 20},
+		{name: "min above max", min: 30, max: 20, expMin: 20, expMax: 20},
+		{name: "inf max", min: 1, max: rate.Inf, expMin: 1, expMax: def},
+		{name: "zero max", min: 0, max: 0, expMin: def / adaptiveRateSteps, expMax: def},
+		{name: "inf min", min: rate.Inf, max: rate.Inf, expMin: def, expMax: def},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			a := newAdaptiveRate(c.min, c.max)
+			require.Equal(t, c.expMin, a.min)
+			require.Equal(t, c.expMax, a.max)
+			require.Equal(t, c.expMax, a.lim.Limit())
+		})
+	}
+}
+
+func TestAdaptiveRate(t *testing.T) {
+	const interval = 5 * time.Millisecond // throttle above 200 rps
+	var (
+		mu        sync.Mutex
+		last      time.Time
+		throttled int
+		served    int
+	)
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		now := time.Now()
+		fast := now.Sub(last) < interval
+		last = now
+		if fast {
+			throttled++
+		} else {
+			served++
+		}
+		mu.Unlock()
+		if fast {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("[]"))
+	}), WithAdaptiveRate(50, 1000))
+	for i := 0; i < 40; i++ {
+		_, _ = c.Books(context.Background())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotZero(t, throttled)
+	require.Greater(t, served, throttled)
+	lim := c.rate.Limit()
+	require.True(t, lim >= 50 && lim <= 1000, "limit: %v", lim)
+}
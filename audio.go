@@ -0,0 +1,70 @@
+package psref
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AudioSpec is a parsed audio specification. See Model.Audio.
+//
+// Fields that cannot be determined from the specification are left empty.
+type AudioSpec struct {
+	Raw          string
+	Speakers     int
+	SpeakerWatts float64 // power of a single speaker
+	Dolby        string  // e.g. "Dolby Atmos", "Dolby Audio"
+	Microphones  int
+	MicArray     bool // microphone array or far-field microphones
+}
+
+var (
+	reAudioSpeakers = regexp.MustCompile(`(?i)(\d+)\s*x?\s*speakers?\b`)
+	reAudioWatts    = regexp.MustCompile(`(?i)(` + reNum + `)\s*W\s*x\s*(\d+)\b`)
+	reAudioDolby    = regexp.MustCompile(`(?i)\bDolby\s+(Atmos|Audio|Voice)\b`)
+	reAudioMics     = regexp.MustCompile(`(?i)\b(\d+|dual|quad)(?:x)?[\s-][^,;]*?\bmic(?:rophone)?s?\b`)
+)
+
+// audioCounts maps words used instead of numbers in audio specifications.
+var audioCounts = map[string]int{"dual": 2, "quad": 4}
+
+// Audio parses the audio specification of the model.
+//
+// Partially recognized specifications are not considered an error.
+// An error is returned only if the model has no audio specification.
+func (m *Model) Audio() (*AudioSpec, error) {
+	s, err := m.detail("Audio")
+	if err != nil {
+		return nil, err
+	}
+	return parseAudio(s), nil
+}
+
+func parseAudio(s string) *AudioSpec {
+	a := &AudioSpec{Raw: s}
+	lower := strings.ToLower(s)
+	if sub := reAudioSpeakers.FindStringSubmatch(s); sub != nil {
+		a.Speakers, _ = strconv.Atoi(sub[1])
+	}
+	if sub := reAudioWatts.FindStringSubmatch(s); sub != nil {
+		a.SpeakerWatts, _ = parseNumber(sub[1])
+		if a.Speakers == 0 {
+			a.Speakers, _ = strconv.Atoi(sub[2])
+		}
+	}
+	if a.Speakers == 0 && strings.Contains(lower, "stereo speakers") {
+		a.Speakers = 2
+	}
+	if sub := reAudioDolby.FindStringSubmatch(s); sub != nil {
+		a.Dolby = "Dolby " + strings.ToUpper(sub[1][:1]) + strings.ToLower(sub[1][1:])
+	}
+	if sub := reAudioMics.FindStringSubmatch(s); sub != nil {
+		if n, ok := audioCounts[strings.ToLower(sub[1])]; ok {
+			a.Microphones = n
+		} else {
+			a.Microphones, _ = strconv.Atoi(sub[1])
+		}
+	}
+	a.MicArray = strings.Contains(lower, "array") || strings.Contains(lower, "far-field")
+	return a
+}
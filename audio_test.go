@@ -0,0 +1,49 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesAudio = []struct {
+	name string
+	in   string
+	exp  AudioSpec
+}{
+	{
+		name: "atmos",
+		in:   "Dolby Atmos, 4 speakers, 4x 360° far-field microphones",
+		exp:  AudioSpec{Speakers: 4, Dolby: "Dolby Atmos", Microphones: 4, MicArray: true},
+	},
+	{
+		name: "stereo",
+		in:   "High Definition (HD) Audio, Realtek ALC3287 codec, Stereo speakers, 2W x2, Dolby Audio, Dual-array microphone",
+		exp:  AudioSpec{Speakers: 2, SpeakerWatts: 2, Dolby: "Dolby Audio", Microphones: 2, MicArray: true},
+	},
+	{
+		name: "stereo only",
+		in:   "Stereo speakers, dual microphones",
+		exp:  AudioSpec{Speakers: 2, Microphones: 2},
+	},
+	{
+		name: "unknown",
+		in:   "HD Audio",
+		exp:  AudioSpec{},
+	},
+}
+
+func TestAudio(t *testing.T) {
+	for _, c := range casesAudio {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{Detail: []KeyValue{{Name: "Audio", Value: c.in}}}
+			got, err := m.Audio()
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *got)
+		})
+	}
+	_, err := (&Model{}).Audio()
+	require.Error(t, err)
+}
@@ -0,0 +1,246 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// forEach calls fn for each index in [0, n) using a bounded number of workers. See WithConcurrency.
+//
+// The first error returned by fn cancels the context passed to the remaining calls and is returned.
+func (c *Client) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := c.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						first = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if first != nil {
+		return first
+	}
+	return ctx.Err()
+}
+
+// BatchProductsByID fetches multiple products concurrently. See ProductByID and WithConcurrency.
+//
+// Products that were not found are omitted from the result. Any other error cancels the remaining requests.
+func (c *Client) BatchProductsByID(ctx context.Context, ids []PID) (map[PID]*Product, error) {
+	var mu sync.Mutex
+	out := make(map[PID]*Product, len(ids))
+	err := c.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		p, err := c.ProductByID(ctx, ids[i])
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		mu.Lock()
+		out[ids[i]] = p
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Models fetches multiple models of the product concurrently. See ModelByID and WithConcurrency.
+//
+// Models that were not found are omitted from the result. Any other error cancels the remaining requests.
+func (c *Client) Models(ctx context.Context, id PID, codes []ModelCode) (map[ModelCode]*Model, error) {
+	var mu sync.Mutex
+	out := make(map[ModelCode]*Model, len(codes))
+	err := c.forEach(ctx, len(codes), func(ctx context.Context, i int) error {
+		m, err := c.ModelByID(ctx, id, codes[i])
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		mu.Lock()
+		out[codes[i]] = m
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangedProducts fetches the latest updates and returns full descriptions of all new and updated products.
+// See Updates and BatchProductsByID.
+//
+// Products are returned in the order of the updates, each product only once. Products that were not found
+// (e.g. withdrawn in the meantime) are skipped. Withdrawn products listed in the updates are not fetched.
+func (c *Client) ChangedProducts(ctx context.Context) ([]*Product, error) {
+	upd, err := c.Updates(ctx)
+	if err != nil {
+		return nil, err
+	} else if upd == nil {
+		return nil, ErrNotFound
+	}
+	var ids []PID
+	seen := make(map[PID]struct{})
+	for _, list := range [][]UpdatedProduct{upd.New, upd.Updated} {
+		for _, p := range list {
+			if _, ok := seen[p.ID]; ok || p.ID == 0 {
+				continue
+			}
+			seen[p.ID] = struct{}{}
+			ids = append(ids, p.ID)
+		}
+	}
+	byID, err := c.BatchProductsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Product, 0, len(byID))
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// SearchModels searches models across multiple products by a keyword, e.g. "RTX 4090".
+// Models are matched by the keyword in the summary or in any specification value, ignoring the case.
+// At most limit models are returned, in the order of the product search results.
+//
+// This is a heavy operation: it sends one search request, one request for each product on the first page
+// of search results (see SearchModelsInProduct) and up to limit model requests. Models with a matching summary
+// are fetched first. Requests are sent concurrently and respect the rate limit. See WithConcurrency and WithRate.
+func (c *Client) SearchModels(ctx context.Context, keyword string, limit int) ([]*Model, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	}
+	keyword = strings.TrimSpace(keyword)
+	res, err := c.Search(ctx, keyword)
+	if err != nil {
+		return nil, err
+	}
+	var ids []PID
+	seenID := make(map[PID]struct{})
+	for _, r := range res {
+		if _, ok := seenID[r.ID]; ok || r.ID == 0 {
+			continue
+		}
+		seenID[r.ID] = struct{}{}
+		ids = append(ids, r.ID)
+	}
+	products := make([]*Product, len(ids))
+	err = c.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		p, err := c.SearchModelsInProduct(ctx, ids[i], keyword)
+		if err == ErrNotFound {
+			return nil
+		}
+		products[i] = p
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	type candidate struct {
+		id   PID
+		code ModelCode
+		hit  bool
+		pos  int
+	}
+	var cands []candidate
+	seenCode := make(map[ModelCode]struct{})
+	hasSummary := HasSummarySubstringFold(keyword)
+	for i, p := range products {
+		if p == nil {
+			continue
+		}
+		for _, m := range p.Models {
+			if _, ok := seenCode[m.Code]; ok {
+				continue
+			}
+			seenCode[m.Code] = struct{}{}
+			cands = append(cands, candidate{id: ids[i], code: m.Code, hit: hasSummary(m), pos: len(cands)})
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		return cands[i].hit && !cands[j].hit
+	})
+	if len(cands) > limit {
+		cands = cands[:limit]
+	}
+	// restore the order of search results
+	sort.Slice(cands, func(i, j int) bool {
+		return cands[i].pos < cands[j].pos
+	})
+	models := make([]*Model, len(cands))
+	err = c.forEach(ctx, len(cands), func(ctx context.Context, i int) error {
+		m, err := c.ModelByID(ctx, cands[i].id, cands[i].code)
+		if err == ErrNotFound {
+			return nil
+		}
+		models[i] = m
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []*Model
+	for i, m := range models {
+		if m != nil && (cands[i].hit || detailsContainFold(m, keyword)) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// detailsContainFold checks if any of the model specification values contain a given substring, ignoring the case.
+func detailsContainFold(m *Model, s string) bool {
+	s = strings.ToLower(s)
+	for _, v := range m.Detail {
+		if strings.Contains(strings.ToLower(v.Value), s) {
+			return true
+		}
+	}
+	return false
+}
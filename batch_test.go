@@ -0,0 +1,198 @@
+package psref
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchProductsByID(t *testing.T) {
+	const workers = 3
+	var cur, max int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&cur, 1)
+		defer atomic.AddInt32(&cur, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/psref/mobile/product/"))
+		if err != nil || id%3 == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Product{ID: PID(id), Key: "P" + strconv.Itoa(id)})
+	}), WithConcurrency(workers))
+
+	var ids []PID
+	for i := 1; i <= 20; i++ {
+		ids = append(ids, PID(i))
+	}
+	res, err := c.BatchProductsByID(context.Background(), ids)
+	require.NoError(t, err)
+	for _, id := range ids {
+		p, ok := res[id]
+		if id%3 == 0 {
+			require.False(t, ok, "%d", id)
+			continue
+		}
+		require.True(t, ok, "%d", id)
+		require.Equal(t, id, p.ID)
+	}
+	require.LessOrEqual(t, int(atomic.LoadInt32(&max)), workers)
+	require.Greater(t, int(atomic.LoadInt32(&max)), 1)
+}
+
+func TestBatchProductsByIDError(t *testing.T) {
+	var calls int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if strings.HasSuffix(r.URL.Path, "/2") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(Product{})
+	}), WithConcurrency(2))
+
+	var ids []PID
+	for i := 1; i <= 50; i++ {
+		ids = append(ids, PID(i))
+	}
+	res, err := c.BatchProductsByID(context.Background(), ids)
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Less(t, int(atomic.LoadInt32(&calls)), len(ids))
+}
+
+func TestModels(t *testing.T) {
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := strings.TrimPrefix(r.URL.Path, "/psref/mobile/Model/1972/")
+		if code == r.URL.Path || code == "MISSING" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Model{RefURL: "ref " + code})
+	}), WithConcurrency(2))
+
+	codes := []ModelCode{"21CB000AUS", "21CB000BUS", "MISSING", "21CB000CUS"}
+	res, err := c.Models(context.Background(), 1972, codes)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	require.NotContains(t, res, ModelCode("MISSING"))
+	for _, code := range []ModelCode{"21CB000AUS", "21CB000BUS", "21CB000CUS"} {
+		m := res[code]
+		require.NotNil(t, m, "%s", code)
+		require.Equal(t, code, m.Code)
+		require.Equal(t, "ref "+string(code), m.RefURL)
+	}
+}
+
+func TestChangedProducts(t *testing.T) {
+	var fetched atomic.Int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/psref/mobile/new":
+			_, _ = w.Write([]byte(`{
+				"LatestUpdateVersion": "Version 593",
+				"New": [{"productId": 3, "title": "C (new model added)"}, {"productId": 1, "title": "A"}],
+				"Updated": [{"productId": 1, "title": "A (spec updated)"}, {"productId": 2, "title": "B"}],
+				"Withdrawn": [{"productId": 4, "title": "D"}]
+			}`))
+			return
+		case "/psref/mobile/product/1", "/psref/mobile/product/3":
+			fetched.Add(1)
+			id := strings.TrimPrefix(r.URL.Path, "/psref/mobile/product/")
+			_, _ = w.Write([]byte(`{"ProductId": ` + id + `}`))
+			return
+		}
+		fetched.Add(1)
+		http.NotFound(w, r)
+	}), WithConcurrency(2))
+	products, err := c.ChangedProducts(context.Background())
+	require.NoError(t, err)
+	var ids []PID
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	require.Equal(t, []PID{3, 1}, ids)
+	require.Equal(t, int32(3), fetched.Load())
+}
+
+func TestChangedProductsNull(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "null"), nil
+	})
+	_, err := c.ChangedProducts(context.Background())
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestSearchModels(t *testing.T) {
+	var requests atomic.Int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var resp any
+		switch r.URL.Path {
+		case "/psref/mobile/searchv3":
+			require.Equal(t, "RTX 4090", r.URL.Query().Get("kw"))
+			resp = map[string]any{"result": []SearchResult{{ID: 1}, {ID: 2}, {ID: 1}}, "total": 3}
+		case "/psref/mobile/product/1":
+			require.Equal(t, "RTX 4090", r.URL.Query().Get("kw"))
+			resp = Product{ID: 1, Models: []ModelInfo{
+				{Code: "M1", Summary: "i9, RTX 4080"},
+				{Code: "M2", Summary: "i9, rtx 4090"},
+			}}
+		case "/psref/mobile/product/2":
+			resp = Product{ID: 2, Models: []ModelInfo{
+				{Code: "M3", Summary: "i9"},
+				{Code: "M4", Summary: "i9, RTX 4090"},
+			}}
+		case "/psref/mobile/Model/1/M1":
+			resp = Model{Detail: []KeyValue{{Name: "Graphics", Value: "NVIDIA GeForce RTX 4080"}}}
+		case "/psref/mobile/Model/1/M2":
+			resp = Model{}
+		case "/psref/mobile/Model/2/M3":
+			resp = Model{Detail: []KeyValue{{Name: "Graphics", Value: "NVIDIA GeForce RTX 4090"}}}
+		case "/psref/mobile/Model/2/M4":
+			resp = Model{}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}), WithConcurrency(2))
+
+	res, err := c.SearchModels(context.Background(), "RTX 4090", 10)
+	require.NoError(t, err)
+	var codes []ModelCode
+	for _, m := range res {
+		codes = append(codes, m.Code)
+	}
+	require.Equal(t, []ModelCode{"M2", "M3", "M4"}, codes)
+	require.Equal(t, int32(7), requests.Load())
+
+	// models with a matching summary are fetched first
+	requests.Store(0)
+	res, err = c.SearchModels(context.Background(), "RTX 4090", 2)
+	require.NoError(t, err)
+	codes = nil
+	for _, m := range res {
+		codes = append(codes, m.Code)
+	}
+	require.Equal(t, []ModelCode{"M2", "M4"}, codes)
+	require.Equal(t, int32(5), requests.Load())
+
+	_, err = c.SearchModels(context.Background(), "RTX 4090", 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package psref
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BatterySpec is a parsed battery specification. See Model.Battery.
+type BatterySpec struct {
+	Raw         string
+	CapacityWh  float64
+	CellCount   int
+	Integrated  bool
+	RapidCharge bool
+
+	// RawLife is the original battery life specification, if any.
+	RawLife string
+	// Life lists battery life estimations, e.g. MobileMark results.
+	Life []BatteryLife
+}
+
+// BatteryLife is a battery life estimation according to a given benchmark.
+type BatteryLife struct {
+	Benchmark string // e.g. "MobileMark 2018", "JEITA 2.0"
+	Hours     float64
+}
+
+var (
+	reBatteryWh    = regexp.MustCompile(`(?i)(` + reNum + `)\s*Wh`)
+	reBatteryCells = regexp.MustCompile(`(?i)(\d+)[\s-]*cell`)
+	reBatteryLife  = regexp.MustCompile(`(?i)((?:MobileMark|JEITA)[\w .]*?)\s*:\s*(?:up to\s*)?(` + reNum + `)\s*h`)
+)
+
+// batteryLifeDetails lists detail names which contain battery life estimations.
+var batteryLifeDetails = []string{"Max Battery Life", "Battery Life"}
+
+// Battery parses the battery specification of the model, including battery life estimations, if available.
+//
+// Partially recognized specifications are not considered an error.
+// An error is returned only if the model has no battery specification.
+func (m *Model) Battery() (*BatterySpec, error) {
+	s, err := m.detail("Battery")
+	if err != nil {
+		return nil, err
+	}
+	b := parseBattery(s)
+	for _, name := range batteryLifeDetails {
+		if v := strings.TrimSpace(m.DetailByName(name)); v != "" {
+			b.RawLife = v
+			b.Life = parseBatteryLife(v)
+			break
+		}
+	}
+	return b, nil
+}
+
+func parseBattery(s string) *BatterySpec {
+	b := &BatterySpec{Raw: s}
+	if sub := reBatteryWh.FindStringSubmatch(s); sub != nil {
+		b.CapacityWh, _ = parseNumber(sub[1])
+	}
+	if sub := reBatteryCells.FindStringSubmatch(s); sub != nil {
+		b.CellCount, _ = strconv.Atoi(sub[1])
+	}
+	lower := strings.ToLower(s)
+	b.Integrated = strings.Contains(lower, "integrated")
+	b.RapidCharge = strings.Contains(lower, "rapid charge")
+	return b
+}
+
+func parseBatteryLife(s string) []BatteryLife {
+	var out []BatteryLife
+	for _, sub := range reBatteryLife.FindAllStringSubmatch(s, -1) {
+		h, _ := parseNumber(sub[2])
+		out = append(out, BatteryLife{Benchmark: strings.TrimSpace(sub[1]), Hours: h})
+	}
+	return out
+}
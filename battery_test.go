@@ -0,0 +1,52 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBattery(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		in   string
+		life string
+		exp  BatterySpec
+	}{
+		{
+			name: "simple",
+			in:   "57Wh",
+			exp:  BatterySpec{CapacityWh: 57},
+		},
+		{
+			name: "full",
+			in:   "Integrated 3-cell 57Wh, supports Rapid Charge",
+			life: "MobileMark 2018: 15.6 hr\nMobileMark 2014: up to 22.5 hr",
+			exp: BatterySpec{
+				CapacityWh: 57, CellCount: 3, Integrated: true, RapidCharge: true,
+				Life: []BatteryLife{
+					{Benchmark: "MobileMark 2018", Hours: 15.6},
+					{Benchmark: "MobileMark 2014", Hours: 22.5},
+				},
+			},
+		},
+		{
+			name: "unknown",
+			in:   "Removable battery",
+			exp:  BatterySpec{},
+		},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{Detail: []KeyValue{{Name: "Battery", Value: c.in}}}
+			if c.life != "" {
+				m.Detail = append(m.Detail, KeyValue{Name: "Max Battery Life", Value: c.life})
+			}
+			got, err := m.Battery()
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			c.exp.RawLife = c.life
+			require.Equal(t, c.exp, *got)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package psref
+
+import "sync"
+
+// retryBudget limits retries relative to successful requests, similar to gRPC retry throttling. See WithRetryBudget.
+//
+// Each failed attempt takes one token from the budget, while each successful one returns ratio tokens.
+// Retries are allowed only while more than a half of the tokens are available.
+type retryBudget struct {
+	max   float64
+	ratio float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+func newRetryBudget(max, ratio float64) *retryBudget {
+	return &retryBudget{max: max, ratio: ratio, tokens: max}
+}
+
+// Success records a successful request attempt.
+func (b *retryBudget) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+// Failure records a failed request attempt and reports if it can be retried.
+func (b *retryBudget) Failure() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens--
+	if b.tokens < 0 {
+		b.tokens = 0
+	}
+	return b.tokens > b.max/2
+}
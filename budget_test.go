@@ -0,0 +1,60 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudget(t *testing.T) {
+	var (
+		calls int32
+		fail  atomic.Bool
+	)
+	fail.Store(true)
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		if fail.Load() {
+			return newResponse(req, http.StatusServiceUnavailable, ""), nil
+		}
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond), WithRetryBudget(0.5))
+	ctx := context.Background()
+
+	// sustained failures: the budget of 10 tokens allows retries until 5 tokens are left,
+	// thus the first request is retried twice, the second one only once, and the rest are never retried
+	for i := 0; i < 10; i++ {
+		_, err := c.Books(ctx)
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(5+(10-2)), atomic.LoadInt32(&calls))
+
+	// successful requests refill the budget
+	fail.Store(false)
+	for i := 0; i < 16; i++ {
+		_, err := c.Books(ctx)
+		require.NoError(t, err)
+	}
+	fail.Store(true)
+	atomic.StoreInt32(&calls, 0)
+	_, err := c.Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	var calls int32
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return newResponse(req, http.StatusServiceUnavailable, ""), nil
+	}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond))
+	for i := 0; i < 10; i++ {
+		_, err := c.Books(context.Background())
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(30), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,114 @@
+package psref
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// cache is an in-memory cache of raw API responses, keyed by request URL.
+//
+// Expiration is checked against a monotonic clock, thus changes of the system time do not affect it,
+// unless the client uses a custom clock (see WithClock). Expired entries are removed lazily on lookup.
+type cache struct {
+	ttl    time.Duration
+	negTTL time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	m  map[string]cacheEntry
+}
+
+// cacheForever is a TTL for entries that never expire.
+const cacheForever = time.Duration(math.MaxInt64)
+
+type cacheEntry struct {
+	data    []byte
+	err     error
+	expires time.Time
+}
+
+func newCache(ttl, negTTL time.Duration, now func() time.Time) *cache {
+	return &cache{ttl: ttl, negTTL: negTTL, now: now, m: make(map[string]cacheEntry)}
+}
+
+// Get returns a cached entry for a given key, if it exists and has not expired yet.
+func (c *cache) Get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		delete(c.m, key)
+		return cacheEntry{}, false
+	}
+	return e, true
+}
+
+// Put stores a response or an error for a given key.
+func (c *cache) Put(key string, data []byte, err error) {
+	ttl := c.ttl
+	if err != nil {
+		ttl = c.negTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	var expires time.Time
+	if ttl != cacheForever {
+		expires = c.now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = cacheEntry{data: data, err: err, expires: expires}
+}
+
+// Clear removes all entries from the cache.
+func (c *cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m = make(map[string]cacheEntry)
+}
+
+// CacheVersion returns the PSREF data version of cached responses. See WithVersionedCache.
+//
+// It returns zero if the versioned cache is disabled, or the version was not determined yet.
+func (c *Client) CacheVersion() uint64 {
+	return c.cacheVersion.Load()
+}
+
+// watchVersion periodically checks the version of PSREF data and drops cached responses when it changes.
+func (c *Client) watchVersion(ctx context.Context) {
+	interval := c.versionInterval
+	if interval <= 0 {
+		interval = apiDefaultVersionCheck
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.checkVersion(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkVersion fetches the current version of PSREF data, bypassing the cache.
+// If the version differs from the cached one, the cache is cleared.
+func (c *Client) checkVersion(ctx context.Context) {
+	var upd *Updates
+	if err := c.getRetry(ctx, "/psref/mobile/new", nil, &upd); err != nil {
+		return
+	}
+	if upd.parse() != nil || !upd.HasVersion() {
+		return
+	}
+	if old := c.cacheVersion.Swap(upd.Version); old != upd.Version && c.cache != nil {
+		c.cache.Clear()
+	}
+}
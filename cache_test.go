@@ -0,0 +1,105 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	var calls int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path == "/psref/mobile/product/2" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ProductId":1,"ProductKey":"P1"}`))
+	}), WithCache(time.Hour), WithNegativeCacheTTL(50*time.Millisecond))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		p, err := c.ProductByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, "P1", p.Key)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.ProductByID(ctx, 2)
+		require.Equal(t, ErrNotFound, err)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	time.Sleep(100 * time.Millisecond)
+	_, err := c.ProductByID(ctx, 2)
+	require.Equal(t, ErrNotFound, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestVersionedCache(t *testing.T) {
+	var (
+		version  atomic.Int32
+		products atomic.Int32
+	)
+	version.Store(593)
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/psref/mobile/new":
+			_, _ = fmt.Fprintf(w, `{"LatestUpdateVersion": "Version %d - Oct.5, 2022"}`, version.Load())
+		default:
+			products.Add(1)
+			_, _ = w.Write([]byte(`{"ProductId":1,"ProductKey":"P1"}`))
+		}
+	}), WithVersionedCache(context.Background(), 20*time.Millisecond))
+	defer c.Close()
+	ctx := context.Background()
+
+	require.Eventually(t, func() bool {
+		return c.CacheVersion() == 593
+	}, time.Second, 5*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		_, err := c.ProductByID(ctx, 1)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), products.Load())
+
+	version.Store(594)
+	require.Eventually(t, func() bool {
+		return c.CacheVersion() == 594
+	}, time.Second, 5*time.Millisecond)
+
+	_, err := c.ProductByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), products.Load())
+}
+
+func TestCacheClock(t *testing.T) {
+	var calls int
+	now := time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithCache(time.Hour), WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Books(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, calls)
+
+	now = now.Add(time.Hour)
+	_, err := c.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	c = NewClient(WithClock(func() time.Time { return now }), WithClock(nil))
+	require.WithinDuration(t, time.Now(), c.now(), time.Minute)
+}
@@ -0,0 +1,56 @@
+package psref
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CameraSpec is a parsed camera specification. See Model.Camera.
+//
+// Fields that cannot be determined from the specification are left empty.
+type CameraSpec struct {
+	Raw            string
+	Resolution     string  // e.g. "1080p", "720p"
+	ResolutionName string  // e.g. "FHD", "HD"
+	Megapixels     float64 // if specified
+	IR             bool    // infrared camera for face authentication
+	Hybrid         bool    // single RGB + IR camera module
+	PrivacyShutter bool
+}
+
+var (
+	reCameraRes     = regexp.MustCompile(`(?i)\b(\d{3,4})p\b`)
+	reCameraResName = regexp.MustCompile(`\b(FHD|QHD|UHD|HD)\+?`)
+	reCameraMP      = regexp.MustCompile(`(?i)(` + reNum + `)\s*MP\b`)
+	reCameraIR      = regexp.MustCompile(`\bIR\b`)
+	reCameraHybrid  = regexp.MustCompile(`(?i)\bhybrid\b|\bRGB\s*\+\s*IR\b`)
+)
+
+// Camera parses the camera specification of the model.
+//
+// Partially recognized specifications are not considered an error.
+// An error is returned only if the model has no camera specification.
+func (m *Model) Camera() (*CameraSpec, error) {
+	s, err := m.detail("Camera")
+	if err != nil {
+		return nil, err
+	}
+	return parseCamera(s), nil
+}
+
+func parseCamera(s string) *CameraSpec {
+	c := &CameraSpec{Raw: s}
+	if sub := reCameraRes.FindStringSubmatch(s); sub != nil {
+		c.Resolution = strings.ToLower(sub[0])
+	}
+	if sub := reCameraResName.FindStringSubmatch(s); sub != nil {
+		c.ResolutionName = sub[0]
+	}
+	if sub := reCameraMP.FindStringSubmatch(s); sub != nil {
+		c.Megapixels, _ = parseNumber(sub[1])
+	}
+	c.IR = reCameraIR.MatchString(s)
+	c.Hybrid = reCameraHybrid.MatchString(s)
+	c.PrivacyShutter = strings.Contains(strings.ToLower(s), "shutter")
+	return c
+}
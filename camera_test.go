@@ -0,0 +1,54 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesCamera = []struct {
+	name string
+	in   string
+	exp  CameraSpec
+}{
+	{
+		name: "shutter",
+		in:   "FHD 1080p with Privacy Shutter",
+		exp:  CameraSpec{Resolution: "1080p", ResolutionName: "FHD", PrivacyShutter: true},
+	},
+	{
+		name: "hybrid",
+		in:   "FHD 1080p + IR Hybrid with Dual Microphone, with Privacy Shutter",
+		exp:  CameraSpec{Resolution: "1080p", ResolutionName: "FHD", IR: true, Hybrid: true, PrivacyShutter: true},
+	},
+	{
+		name: "megapixels",
+		in:   "5MP RGB+IR with E-shutter",
+		exp:  CameraSpec{Megapixels: 5, IR: true, Hybrid: true, PrivacyShutter: true},
+	},
+	{
+		name: "hd",
+		in:   "HD 720p",
+		exp:  CameraSpec{Resolution: "720p", ResolutionName: "HD"},
+	},
+	{
+		name: "unknown",
+		in:   "Camera module",
+		exp:  CameraSpec{},
+	},
+}
+
+func TestCamera(t *testing.T) {
+	for _, c := range casesCamera {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{Detail: []KeyValue{{Name: "Camera", Value: c.in}}}
+			got, err := m.Camera()
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *got)
+		})
+	}
+	_, err := (&Model{}).Camera()
+	require.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package psref
+
+import "strings"
+
+// Specification categories used by Model.DetailsByCategory.
+const (
+	CategoryPerformance  = "Performance"
+	CategoryDisplay      = "Display"
+	CategoryMultimedia   = "Multimedia"
+	CategoryConnectivity = "Connectivity"
+	CategoryInput        = "Input"
+	CategoryPhysical     = "Physical"
+	CategoryPower        = "Power"
+	CategorySecurity     = "Security"
+	CategorySoftware     = "Software"
+	CategoryOther        = "Other"
+)
+
+// DetailCategories maps specification names (see Model.Detail) to categories. See Model.DetailsByCategory.
+//
+// Names are matched exactly first, and then ignoring the case. If multiple names match ignoring the case,
+// the first one in the sorted order is used.
+// Names starting with "Dimensions" or "WLAN" are matched by prefix, since they include units or other details.
+// The map can be modified to adjust the categories, but not concurrently with DetailsByCategory calls.
+var DetailCategories = map[string]string{
+	"Processor":       CategoryPerformance,
+	"Graphics":        CategoryPerformance,
+	"Chipset":         CategoryPerformance,
+	"Memory":          CategoryPerformance,
+	"Memory Slots":    CategoryPerformance,
+	"Max Memory":      CategoryPerformance,
+	"Storage":         CategoryPerformance,
+	"Storage Support": CategoryPerformance,
+	"Storage Slot":    CategoryPerformance,
+
+	"Display":         CategoryDisplay,
+	"Touchscreen":     CategoryDisplay,
+	"Monitor Support": CategoryDisplay,
+
+	"Audio":       CategoryMultimedia,
+	"Audio Chip":  CategoryMultimedia,
+	"Speakers":    CategoryMultimedia,
+	"Microphone":  CategoryMultimedia,
+	"Camera":      CategoryMultimedia,
+	"Card Reader": CategoryMultimedia,
+	"Optical":     CategoryMultimedia,
+
+	"WLAN":     CategoryConnectivity,
+	"WWAN":     CategoryConnectivity,
+	"NFC":      CategoryConnectivity,
+	"Ethernet": CategoryConnectivity,
+	"Ports":    CategoryConnectivity,
+	"Docking":  CategoryConnectivity,
+
+	"Keyboard":        CategoryInput,
+	"Pointing Device": CategoryInput,
+	"Pen":             CategoryInput,
+
+	"Dimensions":    CategoryPhysical,
+	"Weight":        CategoryPhysical,
+	"Case Material": CategoryPhysical,
+	"Color":         CategoryPhysical,
+
+	"Battery":          CategoryPower,
+	"Max Battery Life": CategoryPower,
+	"Power Adapter":    CategoryPower,
+
+	"Security Chip":      CategorySecurity,
+	"Fingerprint Reader": CategorySecurity,
+	"Physical Locks":     CategorySecurity,
+	"Smart Card Reader":  CategorySecurity,
+	"Other Security":     CategorySecurity,
+
+	"Operating System": CategorySoftware,
+	"Bundled Software": CategorySoftware,
+}
+
+// detailPrefixes lists specification names matched by prefix. See DetailCategories.
+var detailPrefixes = []string{"Dimensions", "WLAN"}
+
+// detailCategory returns the category of a specification with a given name.
+func detailCategory(name string) string {
+	if c, ok := DetailCategories[name]; ok {
+		return c
+	}
+	for _, p := range detailPrefixes {
+		if strings.HasPrefix(name, p) {
+			if c, ok := DetailCategories[p]; ok {
+				return c
+			}
+		}
+	}
+	// if multiple names match, pick the first one in the sorted order to keep the result stable
+	best, cat := "", CategoryOther
+	for n, c := range DetailCategories {
+		if strings.EqualFold(n, name) && (best == "" || n < best) {
+			best, cat = n, c
+		}
+	}
+	return cat
+}
+
+// DetailsByCategory groups model specifications by category, e.g. for rendering a structured specification sheet.
+// The order of specifications within each category is preserved. See DetailCategories.
+//
+// Specifications with unknown names are grouped into CategoryOther.
+func (m *Model) DetailsByCategory() map[string][]KeyValue {
+	out := make(map[string][]KeyValue)
+	for _, v := range m.Detail {
+		c := detailCategory(v.Name)
+		out[c] = append(out[c], v)
+	}
+	return out
+}
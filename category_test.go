@@ -0,0 +1,44 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetailsByCategory(t *testing.T) {
+	m := &Model{Detail: []KeyValue{
+		{Name: "Processor", Value: "i7"},
+		{Name: "Memory", Value: "16GB"},
+		{Name: "display", Value: "14\" FHD"},
+		{Name: "WLAN + Bluetooth", Value: "Wi-Fi 6E"},
+		{Name: "Dimensions (WxDxH)", Value: "315.6 x 222.5 x 15.36 mm"},
+		{Name: "Weight", Value: "1.12 kg"},
+		{Name: "Green Certifications", Value: "ENERGY STAR"},
+	}}
+	require.Equal(t, map[string][]KeyValue{
+		CategoryPerformance:  {{Name: "Processor", Value: "i7"}, {Name: "Memory", Value: "16GB"}},
+		CategoryDisplay:      {{Name: "display", Value: "14\" FHD"}},
+		CategoryConnectivity: {{Name: "WLAN + Bluetooth", Value: "Wi-Fi 6E"}},
+		CategoryPhysical:     {{Name: "Dimensions (WxDxH)", Value: "315.6 x 222.5 x 15.36 mm"}, {Name: "Weight", Value: "1.12 kg"}},
+		CategoryOther:        {{Name: "Green Certifications", Value: "ENERGY STAR"}},
+	}, m.DetailsByCategory())
+	require.Empty(t, (&Model{}).DetailsByCategory())
+
+	DetailCategories["Green Certifications"] = "Environment"
+	defer delete(DetailCategories, "Green Certifications")
+	require.Equal(t, []KeyValue{{Name: "Green Certifications", Value: "ENERGY STAR"}}, m.DetailsByCategory()["Environment"])
+}
+
+func TestDetailCategoryFoldStable(t *testing.T) {
+	DetailCategories["PEN"] = CategoryOther
+	DetailCategories["pen"] = CategorySecurity
+	defer func() {
+		delete(DetailCategories, "PEN")
+		delete(DetailCategories, "pen")
+	}()
+	for i := 0; i < 20; i++ {
+		// "PEN" sorts first among the names matching "pEn"
+		require.Equal(t, CategoryOther, detailCategory("pEn"))
+	}
+}
@@ -0,0 +1,116 @@
+package psref
+
+import "encoding/json"
+
+// CleanProduct is a representation of Product with consistent camelCase JSON field names.
+// It is intended for re-serializing PSREF data to other consumers. See Product.Clean.
+type CleanProduct struct {
+	ID        PID              `json:"id"`
+	Key       string           `json:"key"`
+	Name      string           `json:"name"`
+	URL       string           `json:"url,omitempty"`
+	SpecURL   string           `json:"specUrl,omitempty"`
+	PDF       CleanPDF         `json:"pdf"`
+	Image     string           `json:"image,omitempty"`
+	Images    []string         `json:"images,omitempty"`
+	Withdrawn bool             `json:"withdrawn"`
+	Models    []CleanModelInfo `json:"models,omitempty"`
+	Docs      []CleanDoc       `json:"docs,omitempty"`
+}
+
+// CleanPDF lists URLs of regional product specification PDFs. See CleanProduct.
+type CleanPDF struct {
+	US   string `json:"us,omitempty"`
+	EMEA string `json:"emea,omitempty"`
+	WW   string `json:"ww,omitempty"`
+}
+
+// CleanModelInfo is a representation of ModelInfo with consistent JSON field names. See CleanProduct.
+type CleanModelInfo struct {
+	Code    ModelCode `json:"code"`
+	Summary string    `json:"summary,omitempty"`
+	Updated Date      `json:"updated"`
+}
+
+// CleanDoc is a representation of Documentation with consistent JSON field names. See CleanProduct.
+type CleanDoc struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// CleanModel is a representation of Model with consistent JSON field names. See Model.Clean.
+type CleanModel struct {
+	Code      ModelCode   `json:"code"`
+	ProductID PID         `json:"productId"`
+	Product   string      `json:"product"`
+	URL       string      `json:"url,omitempty"`
+	Withdrawn bool        `json:"withdrawn"`
+	Specs     []CleanSpec `json:"specs,omitempty"`
+	Docs      []CleanDoc  `json:"docs,omitempty"`
+	Images    []string    `json:"images,omitempty"`
+}
+
+// CleanSpec is a single model specification value. See CleanModel.
+type CleanSpec struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Clean converts the product to a representation with consistent JSON field names.
+func (p *Product) Clean() CleanProduct {
+	out := CleanProduct{
+		ID:        p.ID,
+		Key:       p.Key,
+		Name:      p.Name,
+		URL:       p.RefURL,
+		SpecURL:   p.SpecURL,
+		PDF:       CleanPDF{US: p.US_Pdf, EMEA: p.EMEA_Pdf, WW: p.WW_Pdf},
+		Image:     p.Image,
+		Images:    p.Images,
+		Withdrawn: p.Withdrawn(),
+		Docs:      cleanDocs(p.Docs),
+	}
+	for _, m := range p.Models {
+		out.Models = append(out.Models, CleanModelInfo{Code: m.Code, Summary: m.Summary, Updated: m.Updated})
+	}
+	return out
+}
+
+// MarshalClean encodes the product as JSON with consistent field names. See Product.Clean.
+//
+// It does not affect the regular JSON encoding, which matches the PSREF API.
+func (p *Product) MarshalClean() ([]byte, error) {
+	return json.Marshal(p.Clean())
+}
+
+// Clean converts the model to a representation with consistent JSON field names.
+func (m *Model) Clean() CleanModel {
+	out := CleanModel{
+		Code:      m.Code,
+		ProductID: m.ID,
+		Product:   m.Name,
+		URL:       m.RefURL,
+		Withdrawn: m.Withdrawn(),
+		Docs:      cleanDocs(m.Docs),
+		Images:    m.Images,
+	}
+	for _, kv := range m.Detail {
+		out.Specs = append(out.Specs, CleanSpec{Name: kv.Name, Value: kv.Value})
+	}
+	return out
+}
+
+// MarshalClean encodes the model as JSON with consistent field names. See Model.Clean.
+//
+// It does not affect the regular JSON encoding, which matches the PSREF API.
+func (m *Model) MarshalClean() ([]byte, error) {
+	return json.Marshal(m.Clean())
+}
+
+func cleanDocs(docs []Documentation) []CleanDoc {
+	var out []CleanDoc
+	for _, d := range docs {
+		out = append(out, CleanDoc{Title: d.Title, URL: d.URL})
+	}
+	return out
+}
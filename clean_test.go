@@ -0,0 +1,52 @@
+package psref
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalClean(t *testing.T) {
+	p := &Product{
+		ID: 1972, Key: "ThinkPad_X1_Carbon_Gen_10", Name: "ThinkPad X1 Carbon Gen 10",
+		US_Pdf: "us.pdf", WW_Pdf: "ww.pdf",
+		Models: []ModelInfo{{Code: "21CB000AUS", Summary: "i5-1240P", Updated: Date(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC))}},
+		Docs:   []Documentation{{ProductID: 1972, Title: "User Guide", URL: "ug.pdf"}},
+	}
+	data, err := p.MarshalClean()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": 1972,
+		"key": "ThinkPad_X1_Carbon_Gen_10",
+		"name": "ThinkPad X1 Carbon Gen 10",
+		"pdf": {"us": "us.pdf", "ww": "ww.pdf"},
+		"withdrawn": false,
+		"models": [{"code": "21CB000AUS", "summary": "i5-1240P", "updated": "2022-03-15"}],
+		"docs": [{"title": "User Guide", "url": "ug.pdf"}]
+	}`, string(data))
+
+	// regular encoding is not affected
+	data, err = json.Marshal(p)
+	require.NoError(t, err)
+	var p2 Product
+	require.NoError(t, json.Unmarshal(data, &p2))
+	require.Equal(t, *p, p2)
+
+	m := &Model{
+		Product:         Product{ID: 1972, Name: "ThinkPad X1 Carbon Gen 10"},
+		WithdrawnStatus: 1,
+		Code:            "21CB000AUS",
+		Detail:          []KeyValue{{Name: "Memory", Value: "16GB"}},
+	}
+	data, err = m.MarshalClean()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"code": "21CB000AUS",
+		"productId": 1972,
+		"product": "ThinkPad X1 Carbon Gen 10",
+		"withdrawn": true,
+		"specs": [{"name": "Memory", "value": "16GB"}]
+	}`, string(data))
+}
@@ -1,13 +1,26 @@
 package psref
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 var (
@@ -26,6 +39,19 @@ func testLogData(t testing.TB, v interface{}) {
 	}
 }
 
+// newMockClient starts a test server with a given handler and returns a client which sends all requests to it.
+// Retries and rate limiting are disabled by default, but can be set in opts.
+func newMockClient(t testing.TB, h http.Handler, opts ...ClientOption) *Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	opts = append([]ClientOption{
+		WithBaseURL(srv.URL),
+		WithRetry(1),
+		WithRate(nil),
+	}, opts...)
+	return NewClient(opts...)
+}
+
 func TestProductTypes(t *testing.T) {
 	types, err := testClient.Products(context.Background())
 	require.NoError(t, err)
@@ -85,3 +111,897 @@ func TestProductByModelCode(t *testing.T) {
 	require.Equal(t, "Lenovo_Flex_5G_14Q8CX05", p.Key)
 	testLogData(t, p)
 }
+
+// modelPagesHandler serves a product with models split into pages of a given size.
+//
+// If echo is set, requests for pages past the end return the last page instead of an empty one.
+func modelPagesHandler(total, size int, echo bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("pagenumber"))
+		if page < 1 {
+			page = 1
+		}
+		pages := (total + size - 1) / size
+		if echo && page > pages {
+			page = pages
+		}
+		p := Product{ID: 1}
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			p.Models = append(p.Models, ModelInfo{Code: ModelCode(fmt.Sprintf("M%03d", i))})
+		}
+		_ = json.NewEncoder(w).Encode(p)
+	}
+}
+
+func TestAllProductModels(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		total int
+		echo  bool
+	}{
+		{name: "short last page", total: 25},
+		{name: "full last page", total: 30},
+		{name: "echo", total: 30, echo: true},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			cli := newMockClient(t, modelPagesHandler(c.total, 10, c.echo))
+			var codes []ModelCode
+			for m, err := range cli.AllProductModels(context.Background(), 1) {
+				require.NoError(t, err)
+				codes = append(codes, m.Code)
+			}
+			require.Len(t, codes, c.total)
+			require.Equal(t, ModelCode("M000"), codes[0])
+
+			p, err := cli.ProductByIDFull(context.Background(), 1)
+			require.NoError(t, err)
+			require.Equal(t, PID(1), p.ID)
+			require.Len(t, p.Models, c.total)
+			require.Equal(t, ModelCode("M000"), p.Models[0].Code)
+			require.Equal(t, ModelCode(fmt.Sprintf("M%03d", c.total-1)), p.Models[c.total-1].Code)
+		})
+	}
+}
+
+func TestProductDocsNull(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "null"), nil
+	})
+	_, err := c.ProductDocs(context.Background(), 1)
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestProductByIDFullNull(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "null"), nil
+	})
+	_, err := c.ProductByIDFull(context.Background(), 1)
+	require.Equal(t, ErrNotFound, err)
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fnc roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fnc(req) }
+
+// newTransportClient returns a client which uses a given function instead of sending HTTP requests.
+func newTransportClient(fnc roundTripFunc, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{
+		WithHTTPClient(&http.Client{Transport: fnc}),
+		WithRate(nil),
+	}, opts...)
+	return NewClient(opts...)
+}
+
+func newResponse(req *http.Request, code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	const tries = 4
+	var times []time.Time
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		times = append(times, time.Now())
+		return newResponse(req, http.StatusServiceUnavailable, ""), nil
+	}, WithRetry(tries), WithBackoff(20*time.Millisecond, time.Second))
+
+	_, err := c.Books(context.Background())
+	require.Error(t, err)
+	require.Len(t, times, tries)
+	var prev time.Duration
+	for i := 1; i < len(times); i++ {
+		d := times[i].Sub(times[i-1])
+		require.Greater(t, d, prev)
+		prev = d
+	}
+}
+
+func TestRetryClientError(t *testing.T) {
+	for _, c := range []struct {
+		code  int
+		tries int
+	}{
+		{code: http.StatusNotFound, tries: 1},
+		{code: http.StatusBadRequest, tries: 1},
+		{code: http.StatusTooManyRequests, tries: 3},
+		{code: http.StatusInternalServerError, tries: 3},
+	} {
+		var tries int
+		cli := newTransportClient(func(req *http.Request) (*http.Response, error) {
+			tries++
+			return newResponse(req, c.code, ""), nil
+		}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond))
+		_, err := cli.Books(context.Background())
+		require.Error(t, err)
+		require.Equal(t, c.tries, tries, "%d", c.code)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var tries int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		tries++
+		cancel()
+		return newResponse(req, http.StatusInternalServerError, ""), nil
+	}, WithRetry(-1), WithBackoff(time.Hour, time.Hour))
+	_, err := c.Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, 1, tries)
+}
+
+func TestStatusError(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusTooManyRequests, ""), nil
+	}, WithRetry(1))
+	_, err := c.Books(context.Background())
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, http.StatusTooManyRequests, se.StatusCode)
+	require.Equal(t, "/psref/mobile/book", se.Path)
+	require.Equal(t, "/psref/mobile/book: status 429 Too Many Requests", err.Error())
+	require.Nil(t, se.Body)
+}
+
+func TestStatusErrorBody(t *testing.T) {
+	body := "<html>Service Unavailable</html>"
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusServiceUnavailable, body), nil
+	}, WithRetry(1))
+	_, err := c.Books(context.Background())
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, body, string(se.Body))
+
+	c = newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusInternalServerError, strings.Repeat("x", 2*maxErrorBody)), nil
+	}, WithRetry(1))
+	_, err = c.Books(context.Background())
+	require.True(t, errors.As(err, &se))
+	require.Len(t, se.Body, maxErrorBody)
+
+	c = newTransportClient(func(req *http.Request) (*http.Response, error) {
+		resp := newResponse(req, http.StatusBadGateway, "")
+		resp.Body = io.NopCloser(iotest.ErrReader(errors.New("broken")))
+		return resp, nil
+	}, WithRetry(1))
+	_, err = c.Books(context.Background())
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, http.StatusBadGateway, se.StatusCode)
+	require.Nil(t, se.Body)
+}
+
+func TestRetryAfter(t *testing.T) {
+	var times []time.Time
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			resp := newResponse(req, http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "1")
+			return resp, nil
+		}
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+	require.GreaterOrEqual(t, times[1].Sub(times[0]), time.Second)
+}
+
+func TestRetryAfterMax(t *testing.T) {
+	var times []time.Time
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		times = append(times, time.Now())
+		resp := newResponse(req, http.StatusServiceUnavailable, "")
+		resp.Header.Set("Retry-After", "3600")
+		return resp, nil
+	}, WithRetry(2), WithMaxRetryAfter(50*time.Millisecond))
+	_, err := c.Books(context.Background())
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, 50*time.Millisecond, se.RetryAfter)
+	require.Len(t, times, 2)
+	d := times[1].Sub(times[0])
+	require.GreaterOrEqual(t, d, 50*time.Millisecond)
+	require.Less(t, d, time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 10, 5, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		in  string
+		exp time.Duration
+		ok  bool
+	}{
+		{in: "", ok: false},
+		{in: "abc", ok: false},
+		{in: "-1", ok: false},
+		{in: "120", exp: 2 * time.Minute, ok: true},
+		{in: "Wed, 05 Oct 2022 12:00:30 GMT", exp: 30 * time.Second, ok: true},
+		{in: "Wed, 05 Oct 2022 11:00:00 GMT", exp: 0, ok: true},
+	} {
+		d, ok := parseRetryAfter(c.in, now)
+		require.Equal(t, c.ok, ok, c.in)
+		require.Equal(t, c.exp, d, c.in)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var ua string
+	handler := func(req *http.Request) (*http.Response, error) {
+		ua = req.Header.Get("User-Agent")
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}
+
+	c := newTransportClient(handler)
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ua, "psref-go/"), ua)
+
+	c = newTransportClient(handler, WithUserAgent("test/1.0"))
+	_, err = c.Books(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "test/1.0", ua)
+}
+
+func TestClientDefaults(t *testing.T) {
+	c := NewClient()
+	require.Equal(t, apiDefaultBackoffBase, c.backoffBase)
+	require.Equal(t, apiDefaultBackoffMax, c.backoffMax)
+	require.Equal(t, apiDefaultRetryAfter, c.maxRetryAfter)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	var tries int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tries, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}), WithRetry(3), WithBackoff(0, 0), WithRequestTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := c.Books(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(3), atomic.LoadInt32(&tries))
+	require.Less(t, time.Since(start), time.Second)
+
+	// canceling the parent context must stop retries
+	atomic.StoreInt32(&tries, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&tries))
+}
+
+type closeTracker struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (t *closeTracker) CloseIdleConnections() { t.closed = true }
+
+func TestClose(t *testing.T) {
+	tr := &closeTracker{RoundTripper: http.DefaultTransport}
+	c := NewClient(WithHTTPClient(&http.Client{Transport: tr}))
+	require.NoError(t, c.Close())
+	require.False(t, tr.closed)
+
+	c = NewClient()
+	require.True(t, c.ownsCli)
+	require.NotSame(t, http.DefaultClient, c.cli)
+	c.cli.Transport = tr
+	require.NoError(t, c.Close())
+	require.True(t, tr.closed)
+}
+
+func TestSearchPage(t *testing.T) {
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/psref/mobile/searchv3", r.URL.Path)
+		page, _ := strconv.Atoi(r.URL.Query().Get("pagenumber"))
+		if page == 0 {
+			page = 1
+		}
+		fmt.Fprintf(w, `{"result":[{"ProductId":%d}],"total":25}`, page)
+	}))
+	res, total, err := c.SearchPage(context.Background(), "x1", 3)
+	require.NoError(t, err)
+	require.Equal(t, 25, total)
+	require.Equal(t, []SearchResult{{ID: 3}}, res)
+
+	res, err = c.Search(context.Background(), "x1")
+	require.NoError(t, err)
+	require.Equal(t, []SearchResult{{ID: 1}}, res)
+}
+
+func TestSearchMalformed(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Query().Get("kw") {
+		case "none":
+			return newResponse(req, http.StatusOK, `{"result":[],"total":0}`), nil
+		case "null":
+			return newResponse(req, http.StatusOK, `{"result":null,"total":0}`), nil
+		}
+		return newResponse(req, http.StatusOK, `{"results":[{"ProductId":1}],"total":1}`), nil
+	})
+	res, err := c.Search(context.Background(), "none")
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	res, err = c.Search(context.Background(), "null")
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	_, err = c.Search(context.Background(), "x1")
+	require.ErrorIs(t, err, ErrMalformedResponse)
+
+	c = newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{"result":[{"ProductId":1}],"total":1}`), nil
+	}, WithLenientDecode(true))
+	res, err = c.Search(context.Background(), "x1")
+	require.NoError(t, err)
+	require.Equal(t, []SearchResult{{ID: 1}}, res)
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	body := `[` + strings.Repeat(`{"BookTitle":"Book"},`, 100) + `{}]`
+	var debug bytes.Buffer
+	tries := 0
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		tries++
+		return newResponse(req, http.StatusOK, body), nil
+	}, WithMaxResponseSize(100), WithRetry(3), WithDebug(&debug))
+	_, err := c.Books(context.Background())
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+	require.Contains(t, err.Error(), "limit is 100 bytes")
+	require.Equal(t, 1, tries)
+	require.NotEmpty(t, debug.String())
+
+	c = newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, body), nil
+	}, WithMaxResponseSize(int64(len(body))))
+	books, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Len(t, books, 101)
+}
+
+func TestProductByIDWithParams(t *testing.T) {
+	var query url.Values
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"ProductId":1,"Spec":"a\\b"}`))
+	}))
+	params := url.Values{"clsf": {"x"}, "api_v": {"100"}}
+	p, err := c.ProductByIDWithParams(context.Background(), 1, params)
+	require.NoError(t, err)
+	require.Equal(t, "a/b", p.SpecURL)
+	require.Equal(t, url.Values{"clsf": {"x"}, "api_v": {apiVersion}}, query)
+	require.Equal(t, url.Values{"clsf": {"x"}, "api_v": {"100"}}, params)
+}
+
+func TestRateWaitCallback(t *testing.T) {
+	var waits []time.Duration
+	lim := rate.NewLimiter(rate.Every(50*time.Millisecond), 1)
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRate(lim), WithRateWaitCallback(func(d time.Duration) {
+		waits = append(waits, d)
+	}))
+	require.InDelta(t, 1.0, c.Tokens(), 0.01)
+	for i := 0; i < 2; i++ {
+		_, err := c.Books(context.Background())
+		require.NoError(t, err)
+	}
+	require.Len(t, waits, 2)
+	require.Less(t, waits[0], 10*time.Millisecond)
+	require.Greater(t, waits[1], 30*time.Millisecond)
+
+	c = newTransportClient(nil, WithRate(nil))
+	require.True(t, math.IsInf(c.Tokens(), +1))
+}
+
+func TestWithTransport(t *testing.T) {
+	var calls int
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newResponse(req, http.StatusOK, "[]"), nil
+	})
+	base := &http.Client{Timeout: 5 * time.Second}
+	c := NewClient(WithHTTPClient(base), WithTransport(rt), WithRate(nil))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Nil(t, base.Transport)
+	require.Equal(t, 5*time.Second, c.cli.Timeout)
+	require.False(t, c.ownsCli)
+
+	c = NewClient(WithTransport(rt))
+	require.Nil(t, http.DefaultClient.Transport)
+}
+
+func TestBooksByGeo(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `[
+			{"BookTitle": "A", "Geo": "WE"},
+			{"BookTitle": "B", "Geo": "NA"},
+			{"BookTitle": "C", "Geo": "na"},
+			{"BookTitle": "D", "Geo": "WW"},
+			{"BookTitle": "E", "Geo": "NA"}
+		]`), nil
+	})
+	books, err := c.BooksByGeo(context.Background(), "Na")
+	require.NoError(t, err)
+	var titles []string
+	for _, b := range books {
+		titles = append(titles, b.Title)
+	}
+	require.Equal(t, []string{"B", "C", "E"}, titles)
+
+	books, err = c.BooksByGeo(context.Background(), "AP")
+	require.NoError(t, err)
+	require.Empty(t, books)
+
+	geos, err := c.Geos(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"NA", "WE", "WW"}, geos)
+}
+
+func TestUpdatesSince(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{
+			"LatestUpdateVersion": "<b>Version 593 - Oct.5, 2022</b>",
+			"New": [{"productId": 1972, "title": "ThinkPad X1 Carbon Gen 10"}]
+		}`), nil
+	})
+	upd, err := c.UpdatesSince(context.Background(), 592)
+	require.NoError(t, err)
+	require.Equal(t, uint64(593), upd.Version)
+	require.Len(t, upd.New, 1)
+
+	for _, v := range []uint64{593, 600} {
+		upd, err = c.UpdatesSince(context.Background(), v)
+		require.NoError(t, err)
+		require.Equal(t, uint64(593), upd.Version)
+		require.Equal(t, "Version 593 - Oct.5, 2022", upd.VersionTitle)
+		require.Empty(t, upd.New)
+		require.Empty(t, upd.Updated)
+		require.Empty(t, upd.Withdrawn)
+	}
+}
+
+func TestUpdatesSinceNull(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "null"), nil
+	})
+	_, err := c.UpdatesSince(context.Background(), 592)
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestUpdatesMalformedVersion(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{"LatestUpdateVersion": "<b>Latest</b>", "New": [{"productId": 1}]}`), nil
+	})
+	upd, err := c.Updates(context.Background())
+	require.Error(t, err)
+	require.NotNil(t, upd)
+	require.False(t, upd.HasVersion())
+	require.Len(t, upd.New, 1)
+}
+
+func TestRetryPredicate(t *testing.T) {
+	var (
+		tries    int
+		attempts []int
+	)
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		tries++
+		if tries == 1 {
+			return newResponse(req, http.StatusBadRequest, ""), nil
+		}
+		return newResponse(req, http.StatusServiceUnavailable, ""), nil
+	}, WithRetry(5), WithBackoff(time.Millisecond, time.Millisecond), WithRetryPredicate(func(err error, attempt int) bool {
+		attempts = append(attempts, attempt)
+		var se *StatusError
+		return errors.As(err, &se) && attempt < 3
+	}))
+	_, err := c.Books(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 3, tries)
+	require.Equal(t, []int{1, 2, 3}, attempts)
+
+	require.False(t, DefaultRetryPredicate(ErrNotFound, 1))
+	require.False(t, DefaultRetryPredicate(&StatusError{StatusCode: http.StatusBadRequest}, 1))
+	require.True(t, DefaultRetryPredicate(&StatusError{StatusCode: http.StatusTooManyRequests}, 1))
+	require.True(t, DefaultRetryPredicate(io.ErrUnexpectedEOF, 1))
+}
+
+func TestModelByIDWithOpts(t *testing.T) {
+	var queries []url.Values
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/psref/mobile/Model/1972/21CB000AUS", req.URL.Path)
+		queries = append(queries, req.URL.Query())
+		return newResponse(req, http.StatusOK, `{"ProductId": 1972}`), nil
+	})
+	m, err := c.ModelByIDWithOpts(context.Background(), 1972, "21CB000AUS", ModelOpts{Clsf: "Laptops", Kw: "x1"})
+	require.NoError(t, err)
+	require.Equal(t, ModelCode("21CB000AUS"), m.Code)
+	require.Equal(t, PID(1972), m.ID)
+
+	_, err = c.ModelByID(context.Background(), 1972, "21CB000AUS")
+	require.NoError(t, err)
+
+	require.Equal(t, []url.Values{
+		{"api_v": {apiVersion}, "clsf": {"Laptops"}, "kw": {"x1"}},
+		{"api_v": {apiVersion}},
+	}, queries)
+}
+
+func TestSearchFirstProduct(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/searchv3":
+			if req.URL.Query().Get("kw") == "X1" {
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972}, {"ProductId": 1973}], "total": 2}`), nil
+			}
+			return newResponse(req, http.StatusOK, `{"result": [], "total": 0}`), nil
+		case "/psref/mobile/product/1972":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ProductKey": "ThinkPad_X1_Carbon_Gen_10"}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	p, err := c.SearchFirstProduct(context.Background(), "X1")
+	require.NoError(t, err)
+	require.Equal(t, PID(1972), p.ID)
+
+	_, err = c.SearchFirstProduct(context.Background(), "X2")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestSearchModelsInProduct(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/psref/mobile/product/1972", req.URL.Path)
+		models := []ModelInfo{{Code: "21CB000AUS", Summary: "i5-1240P"}, {Code: "21CB000BUS", Summary: "i7-1260P"}}
+		if kw := req.URL.Query().Get("kw"); kw != "" {
+			var out []ModelInfo
+			for _, m := range models {
+				if strings.Contains(m.Summary, kw) {
+					out = append(out, m)
+				}
+			}
+			models = out
+		}
+		data, err := json.Marshal(Product{ID: 1972, Models: models})
+		require.NoError(t, err)
+		return newResponse(req, http.StatusOK, string(data)), nil
+	})
+	p, err := c.SearchModelsInProduct(context.Background(), 1972, "i7")
+	require.NoError(t, err)
+	require.Len(t, p.Models, 1)
+	require.Equal(t, ModelCode("21CB000BUS"), p.Models[0].Code)
+
+	p, err = c.ProductByID(context.Background(), 1972)
+	require.NoError(t, err)
+	require.Len(t, p.Models, 2)
+}
+
+func TestDefaultGeo(t *testing.T) {
+	rt := func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `[
+			{"BookTitle": "A", "Geo": "WW"},
+			{"BookTitle": "B", "Geo": "NA"}
+		]`), nil
+	}
+	p := &Product{US_Pdf: "us", WW_Pdf: "ww"}
+
+	c := newTransportClient(rt)
+	require.Equal(t, "WW", c.DefaultGeo())
+	require.Equal(t, "ww", c.PreferredPDF(p))
+	books, err := c.BooksByGeo(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, "A", books[0].Title)
+
+	c = newTransportClient(rt, WithDefaultGeo("na"))
+	require.Equal(t, "na", c.DefaultGeo())
+	require.Equal(t, "us", c.PreferredPDF(p))
+	books, err = c.BooksByGeo(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, "B", books[0].Title)
+
+	books, err = c.BooksByGeo(context.Background(), "WW")
+	require.NoError(t, err)
+	require.Equal(t, "A", books[0].Title)
+}
+
+func TestAmbiguousMatch(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Query().Get("kw") {
+		case "21CB":
+			return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972, "ModelCount": 2}, {"ProductId": 1973, "ModelCount": 1}]}`), nil
+		case "21CB000":
+			return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972, "ModelCount": 2}]}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	ctx := context.Background()
+
+	_, err := c.ProductByModelCode(ctx, "21CB")
+	require.True(t, errors.Is(err, ErrMultipleProducts))
+	require.EqualError(t, err, "more than one product matched")
+	var aerr *AmbiguousMatchError
+	require.True(t, errors.As(err, &aerr))
+	require.Equal(t, ModelCode("21CB"), aerr.Code)
+	require.Equal(t, []PID{1972, 1973}, aerr.Products)
+
+	_, err = c.ModelByCode(ctx, "21CB000")
+	require.True(t, errors.Is(err, ErrMultipleModels))
+	require.EqualError(t, err, "more than one model matched")
+	require.True(t, errors.As(err, &aerr))
+	require.Equal(t, []PID{1972}, aerr.Products)
+}
+
+func TestProductsByModelCode(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("kw") == "21CB" {
+			return newResponse(req, http.StatusOK, `{"result": [
+				{"ProductId": 1972, "ProductName": "A", "ModelCount": 2},
+				{"ProductId": 1973, "ProductName": "B", "ModelCount": 1},
+				{"ProductId": 1972, "ProductName": "A", "ModelCount": 3}
+			]}`), nil
+		}
+		return newResponse(req, http.StatusOK, `{"result": []}`), nil
+	})
+	res, err := c.ProductsByModelCode(context.Background(), "21CB")
+	require.NoError(t, err)
+	require.Equal(t, []SearchResult{
+		{ID: 1972, Name: "A", Models: 5},
+		{ID: 1973, Name: "B", Models: 1},
+	}, res)
+
+	_, err = c.ProductsByModelCode(context.Background(), "XXXX")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestSearchPath(t *testing.T) {
+	var paths []string
+	rt := func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return newResponse(req, http.StatusOK, `{"result": []}`), nil
+	}
+	_, err := newTransportClient(rt).Search(context.Background(), "X1")
+	require.NoError(t, err)
+	_, err = newTransportClient(rt, WithSearchPath("psref/mobile/searchv4")).Search(context.Background(), "X1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/psref/mobile/searchv3", "/psref/mobile/searchv4"}, paths)
+}
+
+func TestRawImageURLs(t *testing.T) {
+	const (
+		image = `http%3a%2f%2fpsref.lenovo.com%2fsyspool%2fSys%2fImage%2fX1.png`
+		spec  = `https://psref.lenovo.com\\syspool\\Sys\\Spec.pdf`
+	)
+	rt := func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ImageForShare": "`+image+`", "Spec": "`+spec+`"}`), nil
+	}
+	p, err := newTransportClient(rt).ProductByID(context.Background(), 1972)
+	require.NoError(t, err)
+	require.Equal(t, "http://psref.lenovo.com/syspool/Sys/Image/X1.png", p.Image)
+	require.Equal(t, "https://psref.lenovo.com/syspool/Sys/Spec.pdf", p.SpecURL)
+
+	p, err = newTransportClient(rt, WithRawImageURLs(true)).ProductByID(context.Background(), 1972)
+	require.NoError(t, err)
+	require.Equal(t, image, p.Image)
+	require.Equal(t, `https://psref.lenovo.com\syspool\Sys\Spec.pdf`, p.SpecURL)
+}
+
+func TestModelWithImages(t *testing.T) {
+	var productCalls int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/Model/1972/A":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972}`), nil
+		case "/psref/mobile/Model/1972/B":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "Images": ["b.png", "b.png", "c.png"]}`), nil
+		case "/psref/mobile/product/1972":
+			productCalls++
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ImageForShare": "p.png", "Images": ["p1.png", "p2.png", "p1.png"]}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	ctx := context.Background()
+
+	m, err := c.ModelWithImages(ctx, 1972, "A")
+	require.NoError(t, err)
+	require.Equal(t, []string{"p1.png", "p2.png"}, m.Images)
+	require.Equal(t, "p.png", m.Image)
+	require.Equal(t, 1, productCalls)
+
+	m, err = c.ModelWithImages(ctx, 1972, "B")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b.png", "c.png"}, m.Images)
+	require.Equal(t, 1, productCalls)
+
+	_, err = c.ModelWithImages(ctx, 1972, "C")
+	require.Equal(t, ErrNotFound, err)
+
+	c = newTransportClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/psref/mobile/Model/1972/A" {
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972}`), nil
+		}
+		return newResponse(req, http.StatusOK, "null"), nil
+	})
+	_, err = c.ModelWithImages(ctx, 1972, "A")
+	require.Equal(t, ErrNotFound, err)
+	_, err = c.ModelWithImages(ctx, 1972, "B")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestPing(t *testing.T) {
+	var calls int
+	code := http.StatusOK
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+		require.Equal(t, "/psref/mobile/new", req.URL.Path)
+		return newResponse(req, code, `{}`), nil
+	}, WithRetry(3), WithCache(time.Hour))
+	ctx := context.Background()
+
+	require.NoError(t, c.Ping(ctx))
+	require.NoError(t, c.Ping(ctx))
+	require.Equal(t, 2, calls)
+
+	code = http.StatusServiceUnavailable
+	err := c.Ping(ctx)
+	var serr *StatusError
+	require.True(t, errors.As(err, &serr))
+	require.Equal(t, 3, calls)
+}
+
+func TestTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	base := "http://" + strings.TrimPrefix(srv.URL, "https://")
+
+	c := NewClient(WithBaseURL(base), WithRate(nil), WithRetry(1), WithTLS(true))
+	require.True(t, strings.HasPrefix(c.baseURL, "https://"))
+	_, err := c.Books(context.Background())
+	require.Error(t, err)
+
+	c = NewClient(WithBaseURL(base), WithRate(nil), WithRetry(1), WithTLS(true), WithInsecureSkipVerify(true))
+	_, err = c.Books(context.Background())
+	require.NoError(t, err)
+	if cfg := http.DefaultTransport.(*http.Transport).TLSClientConfig; cfg != nil {
+		require.False(t, cfg.InsecureSkipVerify)
+	}
+
+	c = NewClient(WithBaseURL(base), WithRate(nil), WithRetry(1))
+	require.Equal(t, base, c.baseURL)
+}
+
+func TestClientWith(t *testing.T) {
+	var tries int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		tries++
+		if tries%3 != 0 {
+			return newResponse(req, http.StatusServiceUnavailable, ""), nil
+		}
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRetry(1), WithBackoff(0, 0), WithCache(time.Hour))
+	ctx := context.Background()
+
+	_, err := c.Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, 1, tries)
+
+	c2 := c.With(RequestRetry(3))
+	_, err = c2.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, tries)
+
+	// cached response is shared with the original client
+	_, err = c.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, tries)
+
+	_, err = c.With(RequestNoCache()).Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, 4, tries)
+
+	require.Equal(t, int64(4), c.Stats().Requests)
+	require.Equal(t, c.Stats(), c2.Stats())
+
+	require.NoError(t, c2.Close())
+	_, err = c.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, tries)
+}
+
+func TestJSONDecoder(t *testing.T) {
+	var calls int
+	dec := func(r io.Reader, v interface{}) error {
+		calls++
+		return json.NewDecoder(r).Decode(v)
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"BookTitle": "ThinkPad"}]`))
+	})
+	ctx := context.Background()
+
+	c := newMockClient(t, h, WithJSONDecoder(dec))
+	books, err := c.Books(ctx)
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, 1, calls)
+
+	calls = 0
+	c = newMockClient(t, h, WithJSONDecoder(dec), WithCache(time.Hour))
+	for i := 0; i < 2; i++ {
+		_, err = c.Books(ctx)
+		require.NoError(t, err)
+	}
+	// raw response, decoding it, decoding from the cache
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	c = newMockClient(t, h, WithJSONDecoder(dec), WithJSONDecoder(nil))
+	_, err = c.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, calls)
+}
+
+func TestProxy(t *testing.T) {
+	var got *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+	u, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	u.User = url.UserPassword("user", "pass")
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL("http://psref.invalid"), WithRate(nil), WithRetry(1), WithProxy(u.String()))
+	_, err = c.Books(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Equal(t, "psref.invalid", got.Host)
+	require.Equal(t, "Basic dXNlcjpwYXNz", got.Header.Get("Proxy-Authorization"))
+
+	for _, s := range []string{"proxy.example.com:3128", "http://[::1"} {
+		c = NewClient(WithBaseURL("http://psref.invalid"), WithRate(nil), WithRetry(1), WithProxy(s))
+		_, err = c.Books(ctx)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,86 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+)
+
+// Comparison is a side-by-side comparison of model specifications. See Client.Compare and CompareAll.
+type Comparison struct {
+	// Models are the compared models, in the order of the request.
+	Models []*Model
+	// Rows are specification values aligned by name.
+	Rows []ComparisonRow
+}
+
+// ComparisonRow is a single specification value of all compared models. See Comparison.
+type ComparisonRow struct {
+	Name string
+	// Values are specification values in the order of Comparison.Models.
+	// The value is empty if the model has no such specification.
+	Values []string
+}
+
+// Differs checks if the specification value is not the same for all models.
+func (r *ComparisonRow) Differs() bool {
+	for i := 1; i < len(r.Values); i++ {
+		if r.Values[i] != r.Values[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Differences returns only the rows with values that differ between models.
+func (c *Comparison) Differences() []ComparisonRow {
+	var out []ComparisonRow
+	for _, r := range c.Rows {
+		if r.Differs() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// CompareAll aligns specifications of multiple models by name.
+//
+// Rows are listed in the order of the first model's Detail, followed by the values present only in the next models.
+// Similar to CompareModels, only the first value is used for duplicate names.
+func CompareAll(models ...*Model) *Comparison {
+	c := &Comparison{Models: models}
+	rows := make(map[string]int)
+	for i, m := range models {
+		for _, v := range m.Detail {
+			j, ok := rows[v.Name]
+			if !ok {
+				j = len(c.Rows)
+				rows[v.Name] = j
+				c.Rows = append(c.Rows, ComparisonRow{Name: v.Name, Values: make([]string, len(models))})
+			} else if i == 0 || c.Rows[j].Values[i] != "" {
+				continue
+			}
+			c.Rows[j].Values[i] = v.Value
+		}
+	}
+	return c
+}
+
+// Compare fetches multiple models concurrently and aligns their specifications. See CompareAll and WithConcurrency.
+//
+// The PSREF API has no comparison endpoint, so models are fetched one by one using ModelByCode.
+// Models may belong to different products. An error is returned if any of the models cannot be found.
+func (c *Client) Compare(ctx context.Context, codes []ModelCode) (*Comparison, error) {
+	models := make([]*Model, len(codes))
+	err := c.forEach(ctx, len(codes), func(ctx context.Context, i int) error {
+		m, err := c.ModelByCode(ctx, codes[i])
+		if err != nil {
+			return fmt.Errorf("model %q: %w", codes[i], err)
+		}
+		models[i] = m
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CompareAll(models...), nil
+}
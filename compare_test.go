@@ -0,0 +1,70 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAll(t *testing.T) {
+	a := &Model{Detail: []KeyValue{
+		{Name: "Processor", Value: "i5"},
+		{Name: "Memory", Value: "16GB"},
+		{Name: "Memory", Value: "32GB"},
+	}}
+	b := &Model{Detail: []KeyValue{
+		{Name: "Memory", Value: "16GB"},
+		{Name: "WWAN", Value: "5G"},
+		{Name: "Processor", Value: "i7"},
+	}}
+	c := &Model{Detail: []KeyValue{
+		{Name: "Memory", Value: "16GB"},
+		{Name: "WWAN", Value: "4G"},
+		{Name: "WWAN", Value: "5G"},
+	}}
+	cmp := CompareAll(a, b, c)
+	require.Equal(t, []ComparisonRow{
+		{Name: "Processor", Values: []string{"i5", "i7", ""}},
+		{Name: "Memory", Values: []string{"16GB", "16GB", "16GB"}},
+		{Name: "WWAN", Values: []string{"", "5G", "4G"}},
+	}, cmp.Rows)
+	require.Equal(t, []ComparisonRow{cmp.Rows[0], cmp.Rows[2]}, cmp.Differences())
+	require.Empty(t, CompareAll(a).Differences())
+	require.Empty(t, CompareAll().Rows)
+}
+
+func TestCompare(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/searchv3":
+			switch req.URL.Query().Get("kw") {
+			case "21CB000AUS":
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972, "ModelCount": 1}]}`), nil
+			case "20XW003GUS":
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1500, "ModelCount": 1}]}`), nil
+			}
+			return newResponse(req, http.StatusOK, `{"result": []}`), nil
+		case "/psref/mobile/Model/1972/21CB000AUS":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ModelCode": "21CB000AUS", "Detail": [{"Name": "Memory", "Value": "16GB"}]}`), nil
+		case "/psref/mobile/Model/1500/20XW003GUS":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1500, "ModelCode": "20XW003GUS", "Detail": [{"Name": "Memory", "Value": "8GB"}]}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	ctx := context.Background()
+
+	cmp, err := c.Compare(ctx, []ModelCode{"21CB000AUS", "20XW003GUS"})
+	require.NoError(t, err)
+	require.Len(t, cmp.Models, 2)
+	require.Equal(t, ModelCode("21CB000AUS"), cmp.Models[0].Code)
+	require.Equal(t, ModelCode("20XW003GUS"), cmp.Models[1].Code)
+	require.Equal(t, []ComparisonRow{{Name: "Memory", Values: []string{"16GB", "8GB"}}}, cmp.Rows)
+
+	_, err = c.Compare(ctx, []ModelCode{"21CB000AUS", "21CB000BUS"})
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.True(t, strings.Contains(err.Error(), "21CB000BUS"))
+}
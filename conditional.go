@@ -0,0 +1,83 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// condEntry holds validators of a previous response, as well as its body, if the client has a cache.
+type condEntry struct {
+	etag    string
+	lastMod string
+	data    []byte
+}
+
+// condStore keeps validators of API responses for conditional requests, keyed by request URL. See WithConditionalGet.
+type condStore struct {
+	mu sync.Mutex
+	m  map[string]condEntry
+}
+
+func newCondStore() *condStore {
+	return &condStore{m: make(map[string]condEntry)}
+}
+
+// Get returns validators for a given key, if any.
+func (s *condStore) Get(key string) (condEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	return e, ok
+}
+
+// Put stores validators for a given key.
+func (s *condStore) Put(key string, e condEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = e
+}
+
+// Clear removes all entries from the store.
+func (s *condStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m = make(map[string]condEntry)
+}
+
+type condKey struct{}
+
+// condState carries validators of a conditional request: ones sent to the server, and ones received in the response.
+type condState struct {
+	prev condEntry
+	next condEntry
+}
+
+// withCondState attaches the state of a conditional request to the context.
+func withCondState(ctx context.Context, st *condState) context.Context {
+	return context.WithValue(ctx, condKey{}, st)
+}
+
+// condStateFromContext returns the state of a conditional request, or nil if the request is not conditional.
+func condStateFromContext(ctx context.Context) *condState {
+	st, _ := ctx.Value(condKey{}).(*condState)
+	return st
+}
+
+// setHeaders adds conditional headers to the request.
+func (st *condState) setHeaders(req *http.Request) {
+	if st.prev.etag != "" {
+		req.Header.Set("If-None-Match", st.prev.etag)
+	}
+	if st.prev.lastMod != "" {
+		req.Header.Set("If-Modified-Since", st.prev.lastMod)
+	}
+}
+
+// readHeaders records validators from the response.
+func (st *condState) readHeaders(resp *http.Response) {
+	st.next = condEntry{
+		etag:    resp.Header.Get("ETag"),
+		lastMod: resp.Header.Get("Last-Modified"),
+	}
+}
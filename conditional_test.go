@@ -0,0 +1,67 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newETagClient(t *testing.T, full, notModified *int32, opts ...ClientOption) *Client {
+	const etag = `"v1"`
+	return newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(full, 1)
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(`[{"BookTitle": "A"}]`))
+	}), opts...)
+}
+
+func TestConditionalGet(t *testing.T) {
+	var full, notModified int32
+	c := newETagClient(t, &full, &notModified, WithConditionalGet(true))
+	ctx := context.Background()
+
+	books, err := c.Books(ctx)
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+
+	_, err = c.Books(ctx)
+	require.Equal(t, ErrNotModified, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&full))
+	require.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+	require.Zero(t, c.Stats().Errors)
+}
+
+func TestConditionalGetCache(t *testing.T) {
+	var full, notModified int32
+	c := newETagClient(t, &full, &notModified, WithConditionalGet(true), WithCache(20*time.Millisecond))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		books, err := c.Books(ctx)
+		require.NoError(t, err)
+		require.Len(t, books, 1)
+		time.Sleep(30 * time.Millisecond)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&full))
+	require.Equal(t, int32(2), atomic.LoadInt32(&notModified))
+}
+
+func TestConditionalGetDisabled(t *testing.T) {
+	var full, notModified int32
+	c := newETagClient(t, &full, &notModified)
+	for i := 0; i < 2; i++ {
+		_, err := c.Books(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&full))
+	require.Zero(t, atomic.LoadInt32(&notModified))
+}
@@ -0,0 +1,111 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CPUSpec is a parsed processor specification. See Model.CPU.
+//
+// Fields that cannot be determined from the specification are left empty.
+type CPUSpec struct {
+	Raw    string // original specification string
+	Vendor string // e.g. "Intel", "AMD"
+	Family string // e.g. "Core i5", "Ryzen 7"
+	Model  string // e.g. "1240P", "5800H"
+
+	TotalCores       int
+	PerformanceCores int
+	EfficiencyCores  int
+	Threads          int
+
+	BaseClock  float64 // in GHz
+	BoostClock float64 // in GHz
+	CacheMB    int
+}
+
+var (
+	reCPUCores   = regexp.MustCompile(`(\d+)C\s*(?:\(([^)]*)\))?\s*/\s*(\d+)T`)
+	reCPUPCores  = regexp.MustCompile(`(\d+)P\b`)
+	reCPUECores  = regexp.MustCompile(`(\d+)(?:LP)?E\b`)
+	reCPUPClock  = regexp.MustCompile(`P-core\s+(` + reNum + `)\s*/\s*(` + reNum + `)\s*GHz`)
+	reCPUClock   = regexp.MustCompile(`(` + reNum + `)\s*/\s*(` + reNum + `)\s*GHz`)
+	reCPUMaxFreq = regexp.MustCompile(`(?i)up to\s+(` + reNum + `)\s*GHz`)
+	reCPUCache   = regexp.MustCompile(`(\d+)\s*MB`)
+)
+
+// CPU parses the processor specification of the model.
+//
+// Both the hybrid format ("Intel Core i5-1240P, 12C (4P + 8E) / 16T, P-core 1.7 / 4.4GHz, E-core 1.2 / 3.3GHz, 12MB")
+// and the simple one ("Intel Core i7-8550U, 4C / 8T, 1.8 / 4.0GHz, 8MB") are supported.
+func (m *Model) CPU() (*CPUSpec, error) {
+	s, err := m.detail("Processor")
+	if err != nil {
+		return nil, err
+	}
+	return parseCPU(s)
+}
+
+func parseCPU(s string) (*CPUSpec, error) {
+	spec := &CPUSpec{Raw: s}
+	name, rest := s, ""
+	if i := strings.IndexAny(s, ",("); i >= 0 {
+		name, rest = strings.TrimSpace(s[:i]), s[i+1:]
+	}
+	spec.Vendor, spec.Family, spec.Model = parseCPUName(name)
+
+	matched := false
+	if sub := reCPUCores.FindStringSubmatch(rest); sub != nil {
+		matched = true
+		spec.TotalCores, _ = strconv.Atoi(sub[1])
+		spec.Threads, _ = strconv.Atoi(sub[3])
+		if sub[2] != "" {
+			for _, c := range reCPUPCores.FindAllStringSubmatch(sub[2], -1) {
+				n, _ := strconv.Atoi(c[1])
+				spec.PerformanceCores += n
+			}
+			for _, c := range reCPUECores.FindAllStringSubmatch(sub[2], -1) {
+				n, _ := strconv.Atoi(c[1])
+				spec.EfficiencyCores += n
+			}
+		}
+	}
+	clock := reCPUPClock.FindStringSubmatch(rest)
+	if clock == nil {
+		clock = reCPUClock.FindStringSubmatch(rest)
+	}
+	if clock != nil {
+		matched = true
+		spec.BaseClock, _ = parseNumber(clock[1])
+		spec.BoostClock, _ = parseNumber(clock[2])
+	} else if sub := reCPUMaxFreq.FindStringSubmatch(rest); sub != nil {
+		matched = true
+		spec.BoostClock, _ = parseNumber(sub[1])
+	}
+	if all := reCPUCache.FindAllStringSubmatch(rest, -1); len(all) != 0 {
+		// AMD lists L2 cache first and L3 last
+		spec.CacheMB, _ = strconv.Atoi(all[len(all)-1][1])
+	}
+	if !matched || spec.Vendor == "" {
+		return nil, fmt.Errorf("unrecognized processor format: %q", s)
+	}
+	return spec, nil
+}
+
+// parseCPUName splits processor name like "Intel Core i5-1240P" into vendor, family and model number.
+func parseCPUName(name string) (vendor, family, model string) {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return "", "", ""
+	}
+	vendor, words = words[0], words[1:]
+	last := words[len(words)-1]
+	words = words[:len(words)-1]
+	if i := strings.LastIndexByte(last, '-'); i > 0 {
+		words = append(words, last[:i])
+		last = last[i+1:]
+	}
+	return vendor, strings.Join(words, " "), last
+}
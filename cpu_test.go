@@ -0,0 +1,80 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesCPU = []struct {
+	name string
+	in   string
+	exp  CPUSpec
+}{
+	{
+		name: "hybrid",
+		in:   "Intel Core i5-1240P, 12C (4P + 8E) / 16T, P-core 1.7 / 4.4GHz, E-core 1.2 / 3.3GHz, 12MB",
+		exp: CPUSpec{
+			Vendor: "Intel", Family: "Core i5", Model: "1240P",
+			TotalCores: 12, PerformanceCores: 4, EfficiencyCores: 8, Threads: 16,
+			BaseClock: 1.7, BoostClock: 4.4, CacheMB: 12,
+		},
+	},
+	{
+		name: "simple",
+		in:   "Intel Core i7-8550U, 4C / 8T, 1.8 / 4.0GHz, 8MB",
+		exp: CPUSpec{
+			Vendor: "Intel", Family: "Core i7", Model: "8550U",
+			TotalCores: 4, Threads: 8,
+			BaseClock: 1.8, BoostClock: 4.0, CacheMB: 8,
+		},
+	},
+	{
+		name: "amd",
+		in:   "AMD Ryzen 7 5800H (8C / 16T, 3.2 / 4.4GHz, 4MB L2 / 16MB L3)",
+		exp: CPUSpec{
+			Vendor: "AMD", Family: "Ryzen 7", Model: "5800H",
+			TotalCores: 8, Threads: 16,
+			BaseClock: 3.2, BoostClock: 4.4, CacheMB: 16,
+		},
+	},
+	{
+		name: "ultra",
+		in:   "Intel Core Ultra 7 155H, 16C (6P + 8E + 2LPE) / 22T, Max Turbo up to 4.8GHz, 24MB",
+		exp: CPUSpec{
+			Vendor: "Intel", Family: "Core Ultra 7", Model: "155H",
+			TotalCores: 16, PerformanceCores: 6, EfficiencyCores: 10, Threads: 22,
+			BoostClock: 4.8, CacheMB: 24,
+		},
+	},
+	{
+		name: "comma decimals",
+		in:   "Intel Core i5-1240P, 12C (4P + 8E) / 16T, P-core 1,7 / 4,4GHz, E-core 1,2 / 3,3GHz, 12MB",
+		exp: CPUSpec{
+			Vendor: "Intel", Family: "Core i5", Model: "1240P",
+			TotalCores: 12, PerformanceCores: 4, EfficiencyCores: 8, Threads: 16,
+			BaseClock: 1.7, BoostClock: 4.4, CacheMB: 12,
+		},
+	},
+}
+
+func TestParseCPU(t *testing.T) {
+	for _, c := range casesCPU {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseCPU(c.in)
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *got)
+		})
+	}
+}
+
+func TestParseCPUError(t *testing.T) {
+	_, err := parseCPU("Unknown")
+	require.Error(t, err)
+
+	m := &Model{}
+	_, err = m.CPU()
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,37 @@
+package psref
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteModelsCSV writes model specifications as CSV. See Model.DetailByName.
+//
+// The header contains "ID" and "Code" columns, followed by requested detail names.
+// Each model is written as a separate row. Missing details produce empty cells. Nil models are skipped.
+func WriteModelsCSV(w io.Writer, models []*Model, columns []string) error {
+	cw := csv.NewWriter(w)
+	row := make([]string, 0, 2+len(columns))
+	row = append(row, "ID", "Code")
+	row = append(row, columns...)
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+	for _, m := range models {
+		if m == nil {
+			continue
+		}
+		details := m.DetailMap()
+		row = row[:0]
+		row = append(row, strconv.FormatUint(uint64(m.ID), 10), string(m.Code))
+		for _, name := range columns {
+			row = append(row, details[name])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,36 @@
+package psref
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteModelsCSV(t *testing.T) {
+	models := []*Model{
+		{
+			Product: Product{ID: 1972},
+			Code:    "21CB000AUS",
+			Detail: []KeyValue{
+				{Name: "Processor", Value: "Intel Core i5-1240P, 12C (4P + 8E) / 16T, 12MB"},
+				{Name: "Memory", Value: "16GB Soldered"},
+			},
+		},
+		nil,
+		{
+			Product: Product{ID: 1234},
+			Code:    "82GU001AUS",
+			Detail: []KeyValue{
+				{Name: "Memory", Value: "8GB"},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	err := WriteModelsCSV(&buf, models, []string{"Processor", "Memory", "Color"})
+	require.NoError(t, err)
+	require.Equal(t, `ID,Code,Processor,Memory,Color
+1972,21CB000AUS,"Intel Core i5-1240P, 12C (4P + 8E) / 16T, 12MB",16GB Soldered,
+1234,82GU001AUS,,8GB,
+`, buf.String())
+}
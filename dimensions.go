@@ -0,0 +1,120 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DimensionSpec is a parsed dimensions and weight specification. See Model.Dimensions.
+//
+// Values listed as a range (e.g. "17.9-18.7mm") set both the value and the corresponding Max field.
+// Otherwise, Max fields are equal to the value.
+type DimensionSpec struct {
+	RawDimensions string
+	RawWeight     string
+
+	WidthMM     float64
+	WidthMaxMM  float64
+	DepthMM     float64
+	DepthMaxMM  float64
+	HeightMM    float64
+	HeightMaxMM float64
+
+	WeightKG    float64
+	WeightMaxKG float64
+}
+
+const (
+	mmPerInch = 25.4
+	kgPerLb   = 0.45359237
+)
+
+const (
+	reDimNum  = `(` + reNum + `(?:\s*-\s*` + reNum + `)?)`
+	reDimUnit = `\s*(?:mm|"|in(?:ch(?:es)?)?)?\s*`
+)
+
+var (
+	reDimensions = regexp.MustCompile(reDimNum + reDimUnit + `x\s*` + reDimNum + reDimUnit + `x\s*` + reDimNum + `\s*(mm|"|in(?:ch(?:es)?)?)`)
+	reWeight     = regexp.MustCompile(`(?i)` + reDimNum + `\s*(kg|g|lbs?|pounds)\b`)
+)
+
+// Dimensions parses the dimensions and the weight of the model.
+//
+// Metric values are preferred; imperial values are converted when metric ones are not available.
+// Partially recognized specifications are not considered an error. An error is returned only
+// when the model has neither dimensions, nor weight specification.
+func (m *Model) Dimensions() (*DimensionSpec, error) {
+	d := &DimensionSpec{}
+	for _, v := range m.Detail {
+		if d.RawDimensions == "" && strings.HasPrefix(v.Name, "Dimensions") {
+			d.RawDimensions = strings.TrimSpace(v.Value)
+		}
+	}
+	d.RawWeight = strings.TrimSpace(m.DetailByName("Weight"))
+	if d.RawDimensions == "" && d.RawWeight == "" {
+		return nil, fmt.Errorf("detail %q: %w", "Dimensions", ErrNotFound)
+	}
+	d.parseDimensions(d.RawDimensions)
+	d.parseWeight(d.RawWeight)
+	return d, nil
+}
+
+// parseRange parses a value like "17.9" or "17.9-18.7", multiplying it by a given factor.
+func parseRange(s string, mul float64) (float64, float64) {
+	lo, hi := s, s
+	if i := strings.IndexByte(s, '-'); i > 0 {
+		lo, hi = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	a, _ := parseNumber(lo)
+	b, _ := parseNumber(hi)
+	return a * mul, b * mul
+}
+
+func (d *DimensionSpec) parseDimensions(s string) {
+	var best []string
+	for _, sub := range reDimensions.FindAllStringSubmatch(s, -1) {
+		if sub[4] == "mm" {
+			best = sub
+			break
+		} else if best == nil {
+			best = sub
+		}
+	}
+	if best == nil {
+		return
+	}
+	mul := 1.0
+	if best[4] != "mm" {
+		mul = mmPerInch
+	}
+	d.WidthMM, d.WidthMaxMM = parseRange(best[1], mul)
+	d.DepthMM, d.DepthMaxMM = parseRange(best[2], mul)
+	d.HeightMM, d.HeightMaxMM = parseRange(best[3], mul)
+}
+
+func (d *DimensionSpec) parseWeight(s string) {
+	var (
+		best []string
+		mul  float64
+	)
+	for _, sub := range reWeight.FindAllStringSubmatch(s, -1) {
+		var m float64
+		switch strings.ToLower(sub[2]) {
+		case "kg":
+			m = 1
+		case "g":
+			m = 0.001
+		default:
+			m = kgPerLb
+		}
+		if best == nil || (mul == kgPerLb && m != kgPerLb) {
+			best, mul = sub, m
+		}
+	}
+	if best == nil {
+		return
+	}
+	d.WeightKG, d.WeightMaxKG = parseRange(best[1], mul)
+}
@@ -0,0 +1,86 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDimensions(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		dims   string
+		weight string
+		exp    DimensionSpec
+	}{
+		{
+			name:   "metric",
+			dims:   "315.6 x 222.5 x 15.36 mm (12.43 x 8.76 x 0.60 inches)",
+			weight: "Starting at 1.12 kg (2.48 lbs)",
+			exp: DimensionSpec{
+				WidthMM: 315.6, WidthMaxMM: 315.6, DepthMM: 222.5, DepthMaxMM: 222.5, HeightMM: 15.36, HeightMaxMM: 15.36,
+				WeightKG: 1.12, WeightMaxKG: 1.12,
+			},
+		},
+		{
+			name:   "comma decimals",
+			dims:   "315,6 x 222,5 x 15,36 mm",
+			weight: "Starting at 1,12 kg",
+			exp: DimensionSpec{
+				WidthMM: 315.6, WidthMaxMM: 315.6, DepthMM: 222.5, DepthMaxMM: 222.5, HeightMM: 15.36, HeightMaxMM: 15.36,
+				WeightKG: 1.12, WeightMaxKG: 1.12,
+			},
+		},
+		{
+			name:   "range",
+			dims:   "359.6mm x 251.8mm x 17.9-18.7mm",
+			weight: "Starting at 1.65-1.8 kg",
+			exp: DimensionSpec{
+				WidthMM: 359.6, WidthMaxMM: 359.6, DepthMM: 251.8, DepthMaxMM: 251.8, HeightMM: 17.9, HeightMaxMM: 18.7,
+				WeightKG: 1.65, WeightMaxKG: 1.8,
+			},
+		},
+		{
+			name:   "imperial",
+			dims:   `10 x 5 x 1 inches`,
+			weight: "2 lbs",
+			exp: DimensionSpec{
+				WidthMM: 254, WidthMaxMM: 254, DepthMM: 127, DepthMaxMM: 127, HeightMM: 25.4, HeightMaxMM: 25.4,
+				WeightKG: 2 * kgPerLb, WeightMaxKG: 2 * kgPerLb,
+			},
+		},
+		{
+			name:   "partial",
+			weight: "Around 980 g",
+			exp: DimensionSpec{
+				WeightKG: 0.98, WeightMaxKG: 0.98,
+			},
+		},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{}
+			if c.dims != "" {
+				m.Detail = append(m.Detail, KeyValue{Name: "Dimensions (WxDxH)", Value: c.dims})
+			}
+			m.Detail = append(m.Detail, KeyValue{Name: "Weight", Value: c.weight})
+			got, err := m.Dimensions()
+			require.NoError(t, err)
+			c.exp.RawDimensions = c.dims
+			c.exp.RawWeight = c.weight
+			require.InDeltaMapValues(t, dimensionValues(c.exp), dimensionValues(*got), 1e-9)
+			require.Equal(t, c.exp.RawDimensions, got.RawDimensions)
+		})
+	}
+	_, err := (&Model{}).Dimensions()
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func dimensionValues(d DimensionSpec) map[string]float64 {
+	return map[string]float64{
+		"w": d.WidthMM, "W": d.WidthMaxMM,
+		"d": d.DepthMM, "D": d.DepthMaxMM,
+		"h": d.HeightMM, "H": d.HeightMaxMM,
+		"kg": d.WeightKG, "KG": d.WeightMaxKG,
+	}
+}
@@ -0,0 +1,122 @@
+package psref
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DisplaySpec is a parsed display specification. See Model.Display.
+//
+// Fields that cannot be determined from the specification are left empty.
+type DisplaySpec struct {
+	Raw              string
+	DiagonalInches   float64
+	ResolutionName   string // e.g. "WUXGA", "FHD"
+	ResolutionWidth  int
+	ResolutionHeight int
+	PanelType        string // e.g. "IPS", "OLED", "TN"
+	BrightnessNits   int
+	RefreshRateHz    int
+	TouchSupport     bool
+	SurfaceFinish    string // e.g. "Anti-glare", "Glossy"
+}
+
+// displayResolutions maps common resolution names to their dimensions.
+var displayResolutions = map[string][2]int{
+	"HD":     {1366, 768},
+	"HD+":    {1600, 900},
+	"FHD":    {1920, 1080},
+	"FHD+":   {1920, 1200},
+	"WUXGA":  {1920, 1200},
+	"2.2K":   {2240, 1400},
+	"2.5K":   {2560, 1600},
+	"QHD":    {2560, 1440},
+	"WQHD":   {2560, 1440},
+	"QHD+":   {2560, 1600},
+	"WQXGA":  {2560, 1600},
+	"2.8K":   {2880, 1800},
+	"3K":     {3072, 1920},
+	"UHD":    {3840, 2160},
+	"4K":     {3840, 2160},
+	"UHD+":   {3840, 2400},
+	"WQUXGA": {3840, 2400},
+}
+
+var (
+	reDisplayDiag   = regexp.MustCompile(`(` + reNum + `)\s*(?:"|''|”|-inch|\s*inch)`)
+	reDisplayRes    = regexp.MustCompile(`(\d{3,4})\s*[xX×]\s*(\d{3,4})`)
+	reDisplayName   = regexp.MustCompile(`(?:^|[\s(])(HD\+?|FHD\+?|WUXGA|QHD\+?|WQHD|WQXGA|WQUXGA|UHD\+?|4K|2\.2K|2\.5K|2\.8K|3K)(?:$|[\s),])`)
+	reDisplayPanel  = regexp.MustCompile(`(?i)\b(IPS|OLED|TN|VA|Mini-?LED|LTPS)\b`)
+	reDisplayNits   = regexp.MustCompile(`(?i)(\d+)\s*nits`)
+	reDisplayHz     = regexp.MustCompile(`(?i)(\d+)\s*Hz`)
+	reDisplayFinish = regexp.MustCompile(`(?i)\b(Anti-glare|Anti-reflection|Anti-smudge|Glossy|Glare)\b`)
+	reDisplayTouch  = regexp.MustCompile(`(?i)\b(non[\s-]?)?touch`)
+)
+
+// Display parses the display specification of the model.
+//
+// Partially recognized specifications are not considered an error; missing values are left empty.
+// An error is returned only if the model has no display specification.
+func (m *Model) Display() (*DisplaySpec, error) {
+	s, err := m.detail("Display")
+	if err != nil {
+		return nil, err
+	}
+	d := parseDisplay(s)
+	if !d.TouchSupport {
+		t := strings.TrimSpace(m.DetailByName("Touchscreen"))
+		if _, non := touchMentions(t); t != "" && !strings.EqualFold(t, "none") && !non {
+			d.TouchSupport = true
+		}
+	}
+	return d, nil
+}
+
+func parseDisplay(s string) *DisplaySpec {
+	d := &DisplaySpec{Raw: s}
+	if sub := reDisplayDiag.FindStringSubmatch(s); sub != nil {
+		d.DiagonalInches, _ = parseNumber(sub[1])
+	}
+	if sub := reDisplayName.FindStringSubmatch(s); sub != nil {
+		d.ResolutionName = sub[1]
+	}
+	if sub := reDisplayRes.FindStringSubmatch(s); sub != nil {
+		d.ResolutionWidth, _ = strconv.Atoi(sub[1])
+		d.ResolutionHeight, _ = strconv.Atoi(sub[2])
+	} else if res, ok := displayResolutions[d.ResolutionName]; ok {
+		d.ResolutionWidth, d.ResolutionHeight = res[0], res[1]
+	}
+	if sub := reDisplayPanel.FindStringSubmatch(s); sub != nil {
+		d.PanelType = strings.ToUpper(sub[1])
+		if strings.HasPrefix(d.PanelType, "MINI") {
+			d.PanelType = "Mini-LED"
+		}
+	}
+	if sub := reDisplayNits.FindStringSubmatch(s); sub != nil {
+		d.BrightnessNits, _ = strconv.Atoi(sub[1])
+	}
+	if sub := reDisplayHz.FindStringSubmatch(s); sub != nil {
+		d.RefreshRateHz, _ = strconv.Atoi(sub[1])
+	}
+	if sub := reDisplayFinish.FindStringSubmatch(s); sub != nil {
+		d.SurfaceFinish = sub[1]
+		if strings.EqualFold(d.SurfaceFinish, "glare") {
+			d.SurfaceFinish = "Glossy"
+		}
+	}
+	d.TouchSupport, _ = touchMentions(s)
+	return d
+}
+
+// touchMentions checks if the specification mentions touch support, or explicitly lacks it (e.g. "Non-touch").
+func touchMentions(s string) (touch, nonTouch bool) {
+	for _, sub := range reDisplayTouch.FindAllStringSubmatch(s, -1) {
+		if sub[1] == "" {
+			touch = true
+		} else {
+			nonTouch = true
+		}
+	}
+	return touch, nonTouch
+}
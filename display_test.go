@@ -0,0 +1,83 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDisplay = []struct {
+	name string
+	in   string
+	exp  DisplaySpec
+}{
+	{
+		name: "full",
+		in:   `14" WUXGA (1920x1200) IPS 400nits Anti-glare, 100% sRGB, Low Power`,
+		exp: DisplaySpec{
+			DiagonalInches: 14, ResolutionName: "WUXGA", ResolutionWidth: 1920, ResolutionHeight: 1200,
+			PanelType: "IPS", BrightnessNits: 400, SurfaceFinish: "Anti-glare",
+		},
+	},
+	{
+		name: "name only",
+		in:   `15.6" FHD IPS 300nits Anti-glare, 144Hz`,
+		exp: DisplaySpec{
+			DiagonalInches: 15.6, ResolutionName: "FHD", ResolutionWidth: 1920, ResolutionHeight: 1080,
+			PanelType: "IPS", BrightnessNits: 300, RefreshRateHz: 144, SurfaceFinish: "Anti-glare",
+		},
+	},
+	{
+		name: "touch",
+		in:   `13.3" 2.8K (2880 x 1800) OLED 400nits Glossy, Touch, 100% DCI-P3`,
+		exp: DisplaySpec{
+			DiagonalInches: 13.3, ResolutionName: "2.8K", ResolutionWidth: 2880, ResolutionHeight: 1800,
+			PanelType: "OLED", BrightnessNits: 400, TouchSupport: true, SurfaceFinish: "Glossy",
+		},
+	},
+	{
+		name: "non-touch",
+		in:   `14" WUXGA (1920x1200) IPS, Anti-glare, Non-touch, 300nits`,
+		exp: DisplaySpec{
+			DiagonalInches: 14, ResolutionName: "WUXGA", ResolutionWidth: 1920, ResolutionHeight: 1200,
+			PanelType: "IPS", BrightnessNits: 300, SurfaceFinish: "Anti-glare",
+		},
+	},
+	{
+		name: "non touch",
+		in:   `14" WUXGA IPS, non touch`,
+		exp:  DisplaySpec{DiagonalInches: 14, ResolutionName: "WUXGA", ResolutionWidth: 1920, ResolutionHeight: 1200, PanelType: "IPS"},
+	},
+	{
+		name: "partial",
+		in:   `Unknown panel`,
+		exp:  DisplaySpec{},
+	},
+}
+
+func TestParseDisplay(t *testing.T) {
+	for _, c := range casesDisplay {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *parseDisplay(c.in))
+		})
+	}
+}
+
+func TestModelDisplayTouch(t *testing.T) {
+	m := &Model{Detail: []KeyValue{
+		{Name: "Display", Value: `14" WUXGA (1920x1200) IPS 300nits`},
+		{Name: "Touchscreen", Value: "10-point Multi-touch"},
+	}}
+	d, err := m.Display()
+	require.NoError(t, err)
+	require.True(t, d.TouchSupport)
+
+	for _, v := range []string{"None", "Non-touch"} {
+		m.Detail[1].Value = v
+		d, err = m.Display()
+		require.NoError(t, err)
+		require.False(t, d.TouchSupport, v)
+	}
+}
@@ -0,0 +1,79 @@
+package psref
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// DownloadPDF downloads a PDF document from a given URL and writes it to w. It returns the number of bytes written.
+// See Product.PreferredPDF.
+//
+// The request respects the client rate limit and will be retried on transient failures,
+// unless some data was already written to w. ErrNotFound is returned if the document does not exist.
+//
+// The URL may use backslashes as returned by the API.
+func (c *Client) DownloadPDF(ctx context.Context, url string, w io.Writer) (int64, error) {
+	url = normalizeURL(url)
+	_, n, err := c.download(ctx, url, w)
+	return n, err
+}
+
+// DownloadImage downloads an image from a given URL and writes it to w.
+// It returns the content type of the image and the number of bytes written.
+//
+// The URL may be either escaped or unescaped, and may use backslashes as returned by the API.
+// The request is handled the same way as in DownloadPDF.
+func (c *Client) DownloadImage(ctx context.Context, url string, w io.Writer) (string, int64, error) {
+	url = normalizeURL(unescapeImage(url))
+	return c.download(ctx, url, w)
+}
+
+// download fetches a given URL and writes the response to w.
+// It returns the content type of the response (either from headers or detected from the content) and the number of bytes written.
+func (c *Client) download(ctx context.Context, url string, w io.Writer) (string, int64, error) {
+	var (
+		ctype string
+		total int64
+	)
+	err := c.retry(ctx, func(ctx context.Context) error {
+		resp, err := c.send(ctx, url, url, nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		sw := &sniffWriter{w: w}
+		n, err := io.Copy(sw, resp.Body)
+		total += n
+		if err != nil {
+			if total != 0 {
+				// cannot restart the download, since w already has some data
+				return noRetry{err}
+			}
+			return err
+		}
+		ctype = resp.Header.Get("Content-Type")
+		if ctype == "" {
+			ctype = http.DetectContentType(sw.head)
+		}
+		return nil
+	})
+	return ctype, total, err
+}
+
+// sniffWriter keeps the first bytes written to it for content type detection.
+type sniffWriter struct {
+	w    io.Writer
+	head []byte
+}
+
+func (w *sniffWriter) Write(p []byte) (int, error) {
+	// http.DetectContentType considers at most 512 bytes
+	if n := 512 - len(w.head); n > 0 {
+		if n > len(p) {
+			n = len(p)
+		}
+		w.head = append(w.head, p[:n]...)
+	}
+	return w.w.Write(p)
+}
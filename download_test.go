@@ -0,0 +1,81 @@
+package psref
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadPDF(t *testing.T) {
+	const data = "%PDF-1.4 test"
+	tries := 0
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spec.pdf":
+			tries++
+			if tries == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte(data))
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithRetry(3), WithBackoff(0, 0))
+	base := c.baseURL
+
+	var buf bytes.Buffer
+	n, err := c.DownloadPDF(context.Background(), base+"/spec.pdf", &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), n)
+	require.Equal(t, data, buf.String())
+	require.Equal(t, 2, tries)
+
+	// raw URL, as returned with WithRawImageURLs
+	buf.Reset()
+	_, err = c.DownloadPDF(context.Background(), base+`\spec.pdf`, &buf)
+	require.NoError(t, err)
+	require.Equal(t, data, buf.String())
+
+	_, err = c.DownloadPDF(context.Background(), base+"/missing.pdf", &buf)
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestDownloadImage(t *testing.T) {
+	png := []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/img/typed.png":
+			w.Header().Set("Content-Type", "image/x-test")
+			_, _ = w.Write(png)
+		case "/img/a.png":
+			// explicitly unset to let the client sniff the type
+			w.Header()["Content-Type"] = nil
+			_, _ = w.Write(png)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	base := c.baseURL
+
+	var buf bytes.Buffer
+	ctype, n, err := c.DownloadImage(context.Background(), base+"/img/typed.png", &buf)
+	require.NoError(t, err)
+	require.Equal(t, "image/x-test", ctype)
+	require.Equal(t, int64(len(png)), n)
+
+	buf.Reset()
+	ctype, n, err = c.DownloadImage(context.Background(), url.QueryEscape(base+"/img/a.png"), &buf)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", ctype)
+	require.Equal(t, int64(len(png)), n)
+	require.Equal(t, png, buf.Bytes())
+
+	_, _, err = c.DownloadImage(context.Background(), base+"\\img\\missing.png", &buf)
+	require.Equal(t, ErrNotFound, err)
+}
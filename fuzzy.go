@@ -0,0 +1,106 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// fuzzyPrefix is the length of the model code prefix used to find candidates in ModelByCodeFuzzy.
+	fuzzyPrefix = 6
+	// maxFuzzyProducts limits the number of products checked by ModelByCodeFuzzy.
+	maxFuzzyProducts = 3
+	// maxFuzzyCandidates limits the number of candidates returned by ModelByCodeFuzzy.
+	maxFuzzyCandidates = 5
+)
+
+// ModelByCodeFuzzy is similar to ModelByCode, but tolerates typos in the model code.
+//
+// If there is no exact match, it searches for models starting with the same 6 characters as the code
+// and returns up to 5 candidates, ordered by the edit distance to the code. The returned model is the closest candidate.
+// The list of candidates is nil for an exact match. ErrNotFound is returned if there are no candidates.
+//
+// Only the first few products matched by the prefix are checked to limit the number of requests.
+func (c *Client) ModelByCodeFuzzy(ctx context.Context, code ModelCode) (*Model, []ModelCode, error) {
+	code = ModelCode(strings.ToUpper(strings.TrimSpace(string(code))))
+	m, err := c.ModelByCode(ctx, code)
+	if !errors.Is(err, ErrNotFound) {
+		return m, nil, err
+	}
+	if len(code) < fuzzyPrefix {
+		return nil, nil, ErrNotFound
+	}
+	prefix := string(code[:fuzzyPrefix])
+	res, err := c.Search(ctx, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := searchIDs(res)
+	if len(ids) > maxFuzzyProducts {
+		ids = ids[:maxFuzzyProducts]
+	}
+	type candidate struct {
+		pid  PID
+		code ModelCode
+		dist int
+	}
+	var cands []candidate
+	seen := make(map[ModelCode]struct{})
+	for _, id := range ids {
+		p, err := c.SearchModelsInProduct(ctx, id, prefix)
+		if err == ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		for _, mi := range p.Models {
+			mc := ModelCode(strings.ToUpper(string(mi.Code)))
+			if _, ok := seen[mc]; ok || !strings.HasPrefix(string(mc), prefix) {
+				continue
+			}
+			seen[mc] = struct{}{}
+			cands = append(cands, candidate{pid: id, code: mi.Code, dist: editDistance(string(code), string(mc))})
+		}
+	}
+	if len(cands) == 0 {
+		return nil, nil, ErrNotFound
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		return cands[i].dist < cands[j].dist
+	})
+	if len(cands) > maxFuzzyCandidates {
+		cands = cands[:maxFuzzyCandidates]
+	}
+	codes := make([]ModelCode, 0, len(cands))
+	for _, cd := range cands {
+		codes = append(codes, cd.code)
+	}
+	m, err = c.ModelByID(ctx, cands[0].pid, cands[0].code)
+	if err != nil {
+		return nil, codes, err
+	}
+	return m, codes, nil
+}
+
+// editDistance returns the Levenshtein distance between two ASCII strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,59 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	require.Equal(t, 0, editDistance("21CB000AUS", "21CB000AUS"))
+	require.Equal(t, 1, editDistance("21CB000AUS", "21CB000BUS"))
+	require.Equal(t, 1, editDistance("21CB00AUS", "21CB000AUS"))
+	require.Equal(t, 2, editDistance("21CB000AUS", "21CB000A"))
+	require.Equal(t, 3, editDistance("", "abc"))
+}
+
+func TestModelByCodeFuzzy(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		switch req.URL.Path {
+		case "/psref/mobile/searchv3":
+			switch q.Get("kw") {
+			case "21CB000AUS":
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972, "ModelCount": 1}]}`), nil
+			case "21CB00":
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972}, {"ProductId": 1973}]}`), nil
+			}
+			return newResponse(req, http.StatusOK, `{"result": []}`), nil
+		case "/psref/mobile/product/1972":
+			require.Equal(t, "21CB00", q.Get("kw"))
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "Models": [
+				{"ModelCode": "21CB00XYZW"}, {"ModelCode": "21CB000AUS"}, {"ModelCode": "21CB000BUS"}
+			]}`), nil
+		case "/psref/mobile/product/1973":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1973, "Models": [{"ModelCode": "21CB000AUK"}, {"ModelCode": "20XW000AUS"}]}`), nil
+		case "/psref/mobile/Model/1972/21CB000AUS":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ModelCode": "21CB000AUS"}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	ctx := context.Background()
+
+	m, cands, err := c.ModelByCodeFuzzy(ctx, "21CB000AUS")
+	require.NoError(t, err)
+	require.Equal(t, ModelCode("21CB000AUS"), m.Code)
+	require.Nil(t, cands)
+
+	m, cands, err = c.ModelByCodeFuzzy(ctx, "21cb00oaus")
+	require.NoError(t, err)
+	require.Equal(t, ModelCode("21CB000AUS"), m.Code)
+	require.Equal(t, []ModelCode{"21CB000AUS", "21CB000BUS", "21CB000AUK", "21CB00XYZW"}, cands)
+
+	_, _, err = c.ModelByCodeFuzzy(ctx, "20XW000AUS")
+	require.Equal(t, ErrNotFound, err)
+	_, _, err = c.ModelByCodeFuzzy(ctx, "21C")
+	require.Equal(t, ErrNotFound, err)
+}
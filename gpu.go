@@ -0,0 +1,133 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GPUSpec is a parsed graphics specification. See Model.GPU.
+type GPUSpec struct {
+	Raw        string // original specification of this GPU
+	Vendor     string // e.g. "Intel", "NVIDIA", "AMD"
+	Model      string // e.g. "Iris Xe Graphics", "GeForce RTX 3060"
+	Integrated bool
+	VRAMGB     int    // dedicated memory size, if any
+	VRAMType   string // e.g. "GDDR6"
+}
+
+var reGPUMem = regexp.MustCompile(`(?i)\b(\d+)\s*GB(?:\s+((?:G|H)?DDR\w*|HBM\w*))?`)
+
+// gpuVendors maps lowercase vendor names to their canonical form.
+var gpuVendors = map[string]string{
+	"intel":    "Intel",
+	"nvidia":   "NVIDIA",
+	"amd":      "AMD",
+	"qualcomm": "Qualcomm",
+	"arm":      "Arm",
+}
+
+// GPU parses the graphics specification of the model.
+//
+// Models with both integrated and discrete graphics return multiple entries.
+// An error is returned only if the specification is missing or none of the entries can be recognized.
+func (m *Model) GPU() ([]GPUSpec, error) {
+	s, err := m.detail("Graphics")
+	if err != nil {
+		return nil, err
+	}
+	return parseGPUs(s)
+}
+
+func parseGPUs(s string) ([]GPUSpec, error) {
+	var out []GPUSpec
+	known := false
+	for _, part := range splitGPUs(s) {
+		g := parseGPU(part)
+		if g.Vendor != "" {
+			known = true
+		}
+		out = append(out, g)
+	}
+	if !known {
+		return nil, fmt.Errorf("unrecognized graphics format: %q", s)
+	}
+	return out, nil
+}
+
+// splitGPUs splits a graphics specification into separate GPUs.
+//
+// Entries are separated by "+" or new lines. Comma-separated parts are considered to be a new GPU
+// only if they start with a vendor name, otherwise they are details of the previous one.
+func splitGPUs(s string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '+' || r == '\n' || r == ';'
+	}) {
+		for i, sub := range strings.Split(part, ",") {
+			sub = strings.TrimSpace(sub)
+			if sub == "" {
+				continue
+			}
+			if i == 0 || gpuVendor(sub) != "" || len(out) == 0 {
+				out = append(out, sub)
+			} else {
+				out[len(out)-1] += ", " + sub
+			}
+		}
+	}
+	return out
+}
+
+// gpuVendor returns a canonical vendor name for a GPU entry, or an empty string if it's unknown.
+func gpuVendor(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		if i > 1 {
+			break
+		}
+		if v, ok := gpuVendors[strings.ToLower(w)]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseGPU(s string) GPUSpec {
+	g := GPUSpec{Raw: s, Vendor: gpuVendor(s)}
+	name := s
+	if i := strings.IndexByte(name, ','); i >= 0 {
+		name = name[:i]
+	}
+	if loc := reGPUMem.FindStringSubmatchIndex(name); loc != nil {
+		g.VRAMGB, _ = strconv.Atoi(name[loc[2]:loc[3]])
+		if loc[4] >= 0 {
+			g.VRAMType = strings.ToUpper(name[loc[4]:loc[5]])
+		}
+		name = name[:loc[0]]
+	}
+	lower := strings.ToLower(name)
+	explicit := false
+	switch {
+	case strings.HasPrefix(lower, "integrated "):
+		g.Integrated, explicit = true, true
+	case strings.HasPrefix(lower, "discrete "):
+		explicit = true
+	}
+	words := strings.Fields(name)
+	if explicit {
+		words = words[1:]
+	}
+	if len(words) != 0 && gpuVendors[strings.ToLower(words[0])] != "" {
+		words = words[1:]
+	}
+	g.Model = strings.Join(words, " ")
+	if !explicit && g.VRAMGB == 0 {
+		switch g.Vendor {
+		case "Intel", "AMD", "Qualcomm", "Arm":
+			g.Integrated = true
+		}
+	}
+	return g
+}
@@ -0,0 +1,57 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesGPU = []struct {
+	name string
+	in   string
+	exp  []GPUSpec
+}{
+	{
+		name: "integrated",
+		in:   "Integrated Intel Iris Xe Graphics",
+		exp: []GPUSpec{
+			{Raw: "Integrated Intel Iris Xe Graphics", Vendor: "Intel", Model: "Iris Xe Graphics", Integrated: true},
+		},
+	},
+	{
+		name: "discrete",
+		in:   "NVIDIA GeForce RTX 3060 6GB GDDR6",
+		exp: []GPUSpec{
+			{Raw: "NVIDIA GeForce RTX 3060 6GB GDDR6", Vendor: "NVIDIA", Model: "GeForce RTX 3060", VRAMGB: 6, VRAMType: "GDDR6"},
+		},
+	},
+	{
+		name: "hybrid",
+		in:   "Integrated Intel UHD Graphics + NVIDIA GeForce RTX 3060 6GB GDDR6, Boost Clock 1425MHz, TGP 130W",
+		exp: []GPUSpec{
+			{Raw: "Integrated Intel UHD Graphics", Vendor: "Intel", Model: "UHD Graphics", Integrated: true},
+			{Raw: "NVIDIA GeForce RTX 3060 6GB GDDR6, Boost Clock 1425MHz, TGP 130W", Vendor: "NVIDIA", Model: "GeForce RTX 3060", VRAMGB: 6, VRAMType: "GDDR6"},
+		},
+	},
+	{
+		name: "comma",
+		in:   "AMD Radeon 680M, AMD Radeon RX 6850M XT 12GB GDDR6",
+		exp: []GPUSpec{
+			{Raw: "AMD Radeon 680M", Vendor: "AMD", Model: "Radeon 680M", Integrated: true},
+			{Raw: "AMD Radeon RX 6850M XT 12GB GDDR6", Vendor: "AMD", Model: "Radeon RX 6850M XT", VRAMGB: 12, VRAMType: "GDDR6"},
+		},
+	},
+}
+
+func TestParseGPU(t *testing.T) {
+	for _, c := range casesGPU {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseGPUs(c.in)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, got)
+		})
+	}
+	_, err := parseGPUs("Unknown")
+	require.Error(t, err)
+}
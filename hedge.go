@@ -0,0 +1,74 @@
+package psref
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// getHedged is similar to getOnce, but sends a second request if the first one has not completed within the hedging delay.
+// The first successful response is used, the other request is cancelled. See WithHedging.
+//
+// Both requests wait for the rate limiter independently. If only one request was sent, its error is returned as-is,
+// otherwise the error is returned only when both requests fail.
+func (c *Client) getHedged(ctx context.Context, path string, vars url.Values, out interface{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		raw json.RawMessage
+		err error
+	}
+	results := make(chan result, 2)
+	send := func() {
+		// each request gets its own copy, since building the URL modifies the query parameters
+		vars := cloneValues(vars)
+		go func() {
+			var raw json.RawMessage
+			err := c.getOnce(ctx, path, vars, &raw)
+			results <- result{raw: raw, err: err}
+		}()
+	}
+	send()
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	pending, hedged := 1, false
+	var first error
+	for {
+		select {
+		case <-timer.C:
+			hedged = true
+			pending++
+			send()
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				cancel()
+				return c.decode(bytes.NewReader(r.raw), out)
+			} else if !hedged {
+				return r.err
+			}
+			if first == nil {
+				first = r.err
+			}
+			if pending == 0 {
+				return first
+			}
+		}
+	}
+}
+
+// cloneValues returns a deep copy of query parameters. It returns nil for nil values.
+func cloneValues(vars url.Values) url.Values {
+	if vars == nil {
+		return nil
+	}
+	out := make(url.Values, len(vars))
+	for k, v := range vars {
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
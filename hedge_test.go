@@ -0,0 +1,94 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedging(t *testing.T) {
+	var calls atomic.Int32
+	cancelled := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				close(cancelled)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`[{"BookTitle": "ThinkPad"}]`))
+	})
+	c := newMockClient(t, h, WithHedging(50*time.Millisecond))
+	start := time.Now()
+	books, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Book{{Title: "ThinkPad"}}, books)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, int32(2), calls.Load())
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("slow request was not cancelled")
+	}
+}
+
+func TestHedgingFast(t *testing.T) {
+	var calls atomic.Int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/psref/mobile/product/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	c := newMockClient(t, h, WithHedging(time.Second))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	_, err = c.ProductByID(context.Background(), 1)
+	require.Equal(t, ErrNotFound, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestHedgingBothFail(t *testing.T) {
+	var calls atomic.Int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newMockClient(t, h, WithHedging(20*time.Millisecond))
+	_, err := c.Books(context.Background())
+	var se *StatusError
+	require.ErrorAs(t, err, &se)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestHedgingQuery(t *testing.T) {
+	var calls atomic.Int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "x1", r.URL.Query().Get("kw"))
+		require.Equal(t, "2", r.URL.Query().Get("pagenumber"))
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":[{"ProductId":1}],"total":1}`))
+	})
+	c := newMockClient(t, h, WithHedging(20*time.Millisecond))
+	res, total, err := c.SearchPage(context.Background(), "x1", 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, []SearchResult{{ID: 1}}, res)
+	require.Equal(t, int32(2), calls.Load())
+}
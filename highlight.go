@@ -0,0 +1,65 @@
+package psref
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchHit is a search result with parts of the product name matching the query. See Client.SearchHighlighted.
+type SearchHit struct {
+	SearchResult
+	// Ranges are byte offsets [start, end) of the name parts matching the query words.
+	// Ranges are sorted and do not overlap.
+	Ranges [][2]int
+}
+
+// SearchHighlighted is similar to Search, but also finds parts of product names matching the query, e.g. for highlighting.
+//
+// Matching is done by the client: each word of the query is matched anywhere in the name, ignoring the case.
+// Results are returned even if their names do not contain the query, since the server may match other fields.
+func (c *Client) SearchHighlighted(ctx context.Context, qu string) ([]SearchHit, error) {
+	res, err := c.Search(ctx, qu)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SearchHit, 0, len(res))
+	for _, r := range res {
+		out = append(out, SearchHit{SearchResult: r, Ranges: HighlightRanges(r.Name, qu)})
+	}
+	return out, nil
+}
+
+// HighlightRanges returns byte offsets [start, end) of all parts of the text matching any word of the query, ignoring the case.
+// Overlapping and adjacent ranges are merged. It returns nil if nothing matches.
+func HighlightRanges(text, query string) [][2]int {
+	var ranges [][2]int
+	for _, word := range strings.Fields(query) {
+		for i := 0; i+len(word) <= len(text); {
+			if strings.EqualFold(text[i:i+len(word)], word) {
+				ranges = append(ranges, [2]int{i, i + len(word)})
+				i += len(word)
+				continue
+			}
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+		}
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+	out := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &out[len(out)-1]
+		if r[0] <= last[1] {
+			last[1] = max(last[1], r[1])
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesHighlight = []struct {
+	name  string
+	text  string
+	query string
+	exp   [][2]int
+}{
+	{name: "single", text: "ThinkPad X1 Carbon", query: "carbon", exp: [][2]int{{12, 18}}},
+	{name: "words", text: "ThinkPad X1 Carbon Gen 10", query: "x1 gen", exp: [][2]int{{9, 11}, {19, 22}}},
+	{name: "repeated", text: "Yoga Slim 7 Pro 7", query: "7", exp: [][2]int{{10, 11}, {16, 17}}},
+	{name: "overlap", text: "ThinkPad", query: "think thinkpad pad", exp: [][2]int{{0, 8}}},
+	{name: "adjacent", text: "ThinkPad", query: "think pad", exp: [][2]int{{0, 8}}},
+	{name: "unicode", text: "Légion 5", query: "5", exp: [][2]int{{8, 9}}},
+	{name: "none", text: "ThinkPad", query: "legion"},
+	{name: "empty", text: "ThinkPad", query: " "},
+}
+
+func TestHighlightRanges(t *testing.T) {
+	for _, c := range casesHighlight {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.exp, HighlightRanges(c.text, c.query))
+		})
+	}
+}
+
+func TestSearchHighlighted(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{"result": [
+			{"ProductId": 1972, "ProductName": "ThinkPad X1 Carbon Gen 10"},
+			{"ProductId": 1973, "ProductName": "ThinkPad T14"}
+		]}`), nil
+	})
+	hits, err := c.SearchHighlighted(context.Background(), "X1 Carbon")
+	require.NoError(t, err)
+	require.Equal(t, []SearchHit{
+		{SearchResult: SearchResult{ID: 1972, Name: "ThinkPad X1 Carbon Gen 10"}, Ranges: [][2]int{{9, 11}, {12, 18}}},
+		{SearchResult: SearchResult{ID: 1973, Name: "ThinkPad T14"}},
+	}, hits)
+}
@@ -0,0 +1,80 @@
+package psref
+
+import (
+	"sort"
+	"strings"
+)
+
+// Index is an in-memory index of product names for fast local lookups. See BuildIndex.
+type Index struct {
+	products []ProductShort
+	names    []string // lowercase names
+}
+
+// BuildIndex builds a product name index from all lineups and series of given product types.
+func BuildIndex(types []ProductType) *Index {
+	idx := &Index{products: FlattenProducts(types)}
+	idx.names = make([]string, 0, len(idx.products))
+	for _, p := range idx.products {
+		idx.names = append(idx.names, strings.ToLower(p.Name))
+	}
+	return idx
+}
+
+// Len returns the number of products in the index.
+func (idx *Index) Len() int {
+	return len(idx.products)
+}
+
+const (
+	rankExact = iota
+	rankPrefix
+	rankWordPrefix
+	rankSubstring
+	rankNone
+)
+
+// Search finds products with names containing the query, ignoring the case.
+// Exact matches are listed first, followed by names starting with the query, names that have a word starting
+// with the query, and finally names containing the query. Limit of zero or less returns all matches.
+func (idx *Index) Search(query string, limit int) []ProductShort {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	type hit struct {
+		rank int
+		i    int
+	}
+	var hits []hit
+	for i, name := range idx.names {
+		if r := matchRank(name, query); r != rankNone {
+			hits = append(hits, hit{rank: r, i: i})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].rank < hits[j].rank
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	out := make([]ProductShort, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, idx.products[h.i])
+	}
+	return out
+}
+
+func matchRank(name, query string) int {
+	switch {
+	case name == query:
+		return rankExact
+	case strings.HasPrefix(name, query):
+		return rankPrefix
+	case strings.Contains(name, " "+query):
+		return rankWordPrefix
+	case strings.Contains(name, query):
+		return rankSubstring
+	}
+	return rankNone
+}
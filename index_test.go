@@ -0,0 +1,41 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	types := []ProductType{{
+		Lineup: []ProductLine{{
+			Series: []Series{
+				{Products: []ProductShort{
+					{ID: 1, Name: "ThinkPad X1 Carbon Gen 10"},
+					{ID: 2, Name: "ThinkPad X13 Gen 3"},
+					{ID: 3, Name: "ThinkPad X1"},
+				}},
+				{Products: []ProductShort{
+					{ID: 4, Name: "X1 Fold"},
+					{ID: 5, Name: "Legion Y9000X1"},
+					{ID: 1, Name: "ThinkPad X1 Carbon Gen 10"},
+				}},
+			},
+		}},
+	}}
+	idx := BuildIndex(types)
+	require.Equal(t, 5, idx.Len())
+
+	ids := func(list []ProductShort) []PID {
+		var out []PID
+		for _, p := range list {
+			out = append(out, p.ID)
+		}
+		return out
+	}
+	require.Equal(t, []PID{4, 1, 2, 3, 5}, ids(idx.Search("x1", 0)))
+	require.Equal(t, []PID{3, 1, 2}, ids(idx.Search("ThinkPad X1", 0)))
+	require.Equal(t, []PID{3, 1}, ids(idx.Search("thinkpad x1", 2)))
+	require.Empty(t, idx.Search("yoga", 0))
+	require.Empty(t, idx.Search(" ", 0))
+}
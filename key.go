@@ -0,0 +1,73 @@
+package psref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProductKeyInfo is a parsed product key. See ParseProductKey.
+type ProductKeyInfo struct {
+	Brand  string // e.g. "Lenovo"; empty if the key starts with the family name
+	Family string // e.g. "Legion", "ThinkPad"
+	Model  string // remaining words separated by spaces, e.g. "5P 15IMH05H", "X1 Carbon Gen 10"
+}
+
+// productBrands lists brand names that may prefix the product family in product keys.
+var productBrands = []string{"Lenovo"}
+
+// productFamilies lists known product families. They are matched even if the key has no underscore after them.
+var productFamilies = []string{
+	"ThinkPad", "ThinkBook", "ThinkCentre", "ThinkStation", "ThinkEdge", "ThinkVision", "ThinkSmart",
+	"IdeaPad", "IdeaCentre", "Yoga", "Legion", "LOQ",
+}
+
+// ParseProductKey splits a product key (see Product.Key) into the brand, family and model.
+//
+// For example, "Lenovo_Legion_5P_15IMH05H" is parsed into "Lenovo", "Legion" and "5P 15IMH05H",
+// and "ThinkPad_X1_Carbon_Gen_10" into "", "ThinkPad" and "X1 Carbon Gen 10".
+// Known families like "ThinkPad" or "IdeaPad" are split from the model even without an underscore, e.g. "IdeaPad5_14ALC05".
+func ParseProductKey(key string) (ProductKeyInfo, error) {
+	var info ProductKeyInfo
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '_' || r == ' '
+	})
+	if len(parts) == 0 {
+		return info, fmt.Errorf("empty product key")
+	}
+	for _, b := range productBrands {
+		if strings.EqualFold(parts[0], b) {
+			info.Brand = parts[0]
+			parts = parts[1:]
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return info, fmt.Errorf("unrecognized product key format: %q", key)
+	}
+	info.Family = parts[0]
+	for _, f := range productFamilies {
+		if len(parts[0]) > len(f) && strings.EqualFold(parts[0][:len(f)], f) {
+			info.Family = parts[0][:len(f)]
+			parts[0] = parts[0][len(f):]
+			info.Model = strings.Join(parts, " ")
+			return info, nil
+		}
+	}
+	info.Model = strings.Join(parts[1:], " ")
+	return info, nil
+}
+
+// String returns the product key in a canonical format, with the brand, the family and each word of the model
+// separated by underscores.
+//
+// It matches Product.Key for keys that separate all words with underscores, e.g. "ThinkPad_X1_Carbon_Gen_10".
+// Keys with a family joined to the model are not preserved: "IdeaPad5_14ALC05" becomes "IdeaPad_5_14ALC05".
+func (k ProductKeyInfo) String() string {
+	var parts []string
+	for _, s := range []string{k.Brand, k.Family, k.Model} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.ReplaceAll(strings.Join(parts, "_"), " ", "_")
+}
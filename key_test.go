@@ -0,0 +1,94 @@
+package psref
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesProductKey = []struct {
+	name string
+	in   string
+	exp  ProductKeyInfo
+	key  string
+}{
+	{
+		name: "brand",
+		in:   "Lenovo_Legion_5P_15IMH05H",
+		exp:  ProductKeyInfo{Brand: "Lenovo", Family: "Legion", Model: "5P 15IMH05H"},
+	},
+	{
+		name: "no brand",
+		in:   "ThinkPad_X1_Carbon_Gen_10",
+		exp:  ProductKeyInfo{Family: "ThinkPad", Model: "X1 Carbon Gen 10"},
+	},
+	{
+		name: "joined family",
+		in:   "IdeaPad5_14ALC05",
+		exp:  ProductKeyInfo{Family: "IdeaPad", Model: "5 14ALC05"},
+		key:  "IdeaPad_5_14ALC05",
+	},
+	{
+		name: "joined family with brand",
+		in:   "Lenovo_YogaSlim_7_14ARE05",
+		exp:  ProductKeyInfo{Brand: "Lenovo", Family: "Yoga", Model: "Slim 7 14ARE05"},
+		key:  "Lenovo_Yoga_Slim_7_14ARE05",
+	},
+	{
+		name: "unknown family",
+		in:   "Lenovo_V15_G2_ITL",
+		exp:  ProductKeyInfo{Brand: "Lenovo", Family: "V15", Model: "G2 ITL"},
+	},
+	{
+		name: "family only",
+		in:   "Yoga",
+		exp:  ProductKeyInfo{Family: "Yoga"},
+	},
+}
+
+func TestParseProductKey(t *testing.T) {
+	for _, c := range casesProductKey {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ParseProductKey(c.in)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, got)
+			key := c.key
+			if key == "" {
+				key = c.in
+			}
+			require.Equal(t, key, got.String())
+		})
+	}
+	for _, s := range []string{"", "__", "Lenovo"} {
+		_, err := ParseProductKey(s)
+		require.Error(t, err, "%q", s)
+	}
+}
+
+func TestProductKeyRoundTrip(t *testing.T) {
+	reKey := regexp.MustCompile(`"ProductKey":\s*"([^"]+)"`)
+	keys := make(map[string]struct{})
+	err := filepath.WalkDir("psreftest/fixtures", func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, sub := range reKey.FindAllSubmatch(data, -1) {
+			keys[string(sub[1])] = struct{}{}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+	for key := range keys {
+		info, err := ParseProductKey(key)
+		require.NoError(t, err, key)
+		require.Equal(t, key, info.String())
+	}
+}
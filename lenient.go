@@ -0,0 +1,155 @@
+package psref
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError is an error decoding a single field of an API response. See WithLenientDecode.
+type FieldError struct {
+	Path string // e.g. "Models[3].Updated"
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return "field " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// decodeErrorSetter is implemented by response types that record field errors. See WithLenientDecode.
+type decodeErrorSetter interface {
+	setDecodeErrors(errs []FieldError)
+}
+
+// decode decodes a JSON response to out, respecting WithJSONDecoder and WithLenientDecode.
+func (c *Client) decode(r io.Reader, out interface{}) error {
+	if !c.lenient {
+		return c.decodeJSON(r, out)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		// report the syntax error as usual
+		return json.Unmarshal(data, out)
+	}
+	errs := unmarshalLenient(data, out)
+	if len(errs) == 0 {
+		return nil
+	}
+	for rv := reflect.ValueOf(out); rv.Kind() == reflect.Pointer && !rv.IsNil(); rv = rv.Elem() {
+		if s, ok := rv.Interface().(decodeErrorSetter); ok {
+			s.setDecodeErrors(errs)
+			break
+		}
+	}
+	return nil
+}
+
+// unmarshalLenient is similar to json.Unmarshal, but decodes struct fields and slice elements one by one.
+// Values that fail to decode are left empty and reported as field errors. The data must be a valid JSON.
+func unmarshalLenient(data []byte, out interface{}) []FieldError {
+	var errs []FieldError
+	lenientValue(data, reflect.ValueOf(out).Elem(), "", &errs)
+	return errs
+}
+
+func lenientValue(data []byte, v reflect.Value, path string, errs *[]FieldError) {
+	if _, ok := v.Addr().Interface().(json.Unmarshaler); ok {
+		lenientLeaf(data, v, path, errs)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if string(bytes.TrimSpace(data)) == "null" {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		lenientValue(data, v.Elem(), path, errs)
+		return
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			*errs = append(*errs, FieldError{Path: path, Err: err})
+			return
+		}
+		lenientFields(obj, v, path, errs)
+		return
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var arr []json.RawMessage
+		if err := json.Unmarshal(data, &arr); err != nil {
+			*errs = append(*errs, FieldError{Path: path, Err: err})
+			return
+		} else if arr == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), len(arr), len(arr))
+		for i, raw := range arr {
+			lenientValue(raw, s.Index(i), path+"["+strconv.Itoa(i)+"]", errs)
+		}
+		v.Set(s)
+		return
+	}
+	lenientLeaf(data, v, path, errs)
+}
+
+func lenientLeaf(data []byte, v reflect.Value, path string, errs *[]FieldError) {
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		v.Set(reflect.Zero(v.Type()))
+		*errs = append(*errs, FieldError{Path: path, Err: err})
+	}
+}
+
+// lenientFields decodes struct fields from a JSON object, following the field naming rules of encoding/json.
+func lenientFields(obj map[string]json.RawMessage, v reflect.Value, path string, errs *[]FieldError) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			lenientFields(obj, v.Field(i), path, errs)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		raw, ok := obj[name]
+		if !ok {
+			for k, r := range obj {
+				if strings.EqualFold(k, name) {
+					raw, ok = r, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		fpath := name
+		if path != "" {
+			fpath = path + "." + name
+		}
+		lenientValue(raw, v.Field(i), fpath, errs)
+	}
+}
@@ -0,0 +1,68 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLenientDecode(t *testing.T) {
+	fnc := func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/product/1972":
+			return newResponse(req, http.StatusOK, `{
+				"ProductId": 1972,
+				"ProductKey": "ThinkPad_X1_Carbon_Gen_10",
+				"P_WdStatus": "active",
+				"Models": [
+					{"ModelCode": "21CB000AUS", "Updated": "2022-03-15"},
+					{"ModelCode": "21CB000BUS", "Updated": "15/03/2022"}
+				]
+			}`), nil
+		case "/psref/mobile/Model/1972/21CB000AUS":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ModelCode": "21CB000AUS", "M_WdStatus": 1.5, "Detail": [{"Name": "Memory", "Value": "16GB"}]}`), nil
+		case "/psref/mobile/book":
+			return newResponse(req, http.StatusOK, `[{"BookTitle": "x"`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	}
+	ctx := context.Background()
+
+	c := newTransportClient(fnc, WithRetry(1))
+	_, err := c.ProductByID(ctx, 1972)
+	require.Error(t, err)
+
+	for _, cache := range []bool{false, true} {
+		opts := []ClientOption{WithRetry(1), WithLenientDecode(true)}
+		if cache {
+			opts = append(opts, WithCache(time.Hour))
+		}
+		c = newTransportClient(fnc, opts...)
+
+		p, err := c.ProductByID(ctx, 1972)
+		require.NoError(t, err)
+		require.Equal(t, PID(1972), p.ID)
+		require.Equal(t, "ThinkPad_X1_Carbon_Gen_10", p.Key)
+		require.Zero(t, p.WithdrawnStatus)
+		require.Len(t, p.Models, 2)
+		require.Equal(t, ModelCode("21CB000BUS"), p.Models[1].Code)
+		require.True(t, p.Models[1].Updated.IsZero())
+		require.False(t, p.Models[0].Updated.IsZero())
+		require.Len(t, p.DecodeErrors, 2)
+		require.Equal(t, "P_WdStatus", p.DecodeErrors[0].Path)
+		require.Equal(t, "Models[1].Updated", p.DecodeErrors[1].Path)
+
+		m, err := c.ModelByID(ctx, 1972, "21CB000AUS")
+		require.NoError(t, err)
+		require.Equal(t, PID(1972), m.ID)
+		require.Equal(t, "16GB", m.DetailByName("Memory"))
+		require.Len(t, m.DecodeErrors, 1)
+		require.Equal(t, "M_WdStatus", m.DecodeErrors[0].Path)
+
+		_, err = c.Books(ctx)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,121 @@
+package psref
+
+import (
+	"slices"
+	"strings"
+)
+
+// FlattenProducts returns all products from all lineups and series of given product types.
+// Products are listed in the order of the tree, each product is listed only once.
+func FlattenProducts(types []ProductType) []ProductShort {
+	var out []ProductShort
+	seen := make(map[PID]struct{})
+	for _, t := range types {
+		for _, l := range t.Lineup {
+			for _, s := range l.Series {
+				for _, p := range s.Products {
+					if _, ok := seen[p.ID]; ok {
+						continue
+					}
+					seen[p.ID] = struct{}{}
+					out = append(out, p)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// FlattenSeries returns all series from all lineups of given product types in the order of the tree.
+//
+// Series with the same name may be listed in multiple lineups. They are merged into one,
+// with products of all occurrences listed once. Product slices of the input are not modified.
+func FlattenSeries(types []ProductType) []Series {
+	var out []Series
+	byName := make(map[string]int)
+	seen := make(map[string]map[PID]struct{})
+	for _, t := range types {
+		for _, l := range t.Lineup {
+			for _, s := range l.Series {
+				i, ok := byName[s.Name]
+				if !ok {
+					i = len(out)
+					byName[s.Name] = i
+					seen[s.Name] = make(map[PID]struct{})
+					out = append(out, Series{Name: s.Name})
+				}
+				ids := seen[s.Name]
+				for _, p := range s.Products {
+					if _, ok := ids[p.ID]; ok {
+						continue
+					}
+					ids[p.ID] = struct{}{}
+					out[i].Products = append(out[i].Products, p)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// FindProductPath finds a product with a given ID in the product type tree and returns the names
+// of its product type, lineup and series, e.g. for breadcrumbs. The first occurrence of the product is used.
+func FindProductPath(types []ProductType, id PID) (typeName, lineName, seriesName string, ok bool) {
+	for _, t := range types {
+		for _, l := range t.Lineup {
+			for _, s := range l.Series {
+				for _, p := range s.Products {
+					if p.ID == id {
+						return t.Name, l.Name, s.Name, true
+					}
+				}
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// Classification is a top-level product classification, e.g. a product family like "ThinkPad" or "Legion". See ProductType.
+type Classification string
+
+// Known product classifications.
+const (
+	ClassificationUnknown      = Classification("")
+	ClassificationThinkPad     = Classification("ThinkPad")
+	ClassificationThinkBook    = Classification("ThinkBook")
+	ClassificationThinkCentre  = Classification("ThinkCentre")
+	ClassificationThinkStation = Classification("ThinkStation")
+	ClassificationThinkEdge    = Classification("ThinkEdge")
+	ClassificationThinkVision  = Classification("ThinkVision")
+	ClassificationThinkSmart   = Classification("ThinkSmart")
+	ClassificationIdeaPad      = Classification("IdeaPad")
+	ClassificationIdeaCentre   = Classification("IdeaCentre")
+	ClassificationYoga         = Classification("Yoga")
+	ClassificationLegion       = Classification("Legion")
+	ClassificationLOQ          = Classification("LOQ")
+	ClassificationLenovo       = Classification("Lenovo")
+)
+
+var knownClassifications = []Classification{
+	ClassificationThinkPad, ClassificationThinkBook, ClassificationThinkCentre, ClassificationThinkStation,
+	ClassificationThinkEdge, ClassificationThinkVision, ClassificationThinkSmart,
+	ClassificationIdeaPad, ClassificationIdeaCentre, ClassificationYoga, ClassificationLegion, ClassificationLOQ,
+	ClassificationLenovo,
+}
+
+// Classification returns a known classification of the product type, ignoring the case of its name.
+// It returns ClassificationUnknown for other names; the name itself is still available in ProductType.Name.
+func (p *ProductType) Classification() Classification {
+	name := strings.TrimSpace(p.Name)
+	for _, c := range knownClassifications {
+		if strings.EqualFold(name, string(c)) {
+			return c
+		}
+	}
+	return ClassificationUnknown
+}
+
+// Known checks if the classification is one of the known ones.
+func (c Classification) Known() bool {
+	return slices.Contains(knownClassifications, c)
+}
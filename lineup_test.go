@@ -0,0 +1,72 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testLineup = []ProductType{
+	{Name: "Laptops", Lineup: []ProductLine{
+		{Name: "ThinkPad", Series: []Series{
+			{Name: "ThinkPad X1", Products: []ProductShort{{ID: 1, Name: "X1 Carbon"}, {ID: 2, Name: "X1 Yoga"}}},
+			{Name: "ThinkPad T", Products: []ProductShort{{ID: 3, Name: "T14"}}},
+		}},
+	}},
+	{Name: "Workstations", Lineup: []ProductLine{
+		{Name: "ThinkPad", Series: []Series{
+			{Name: "ThinkPad P", Products: []ProductShort{{ID: 4, Name: "P1"}}},
+			{Name: "ThinkPad X1", Products: []ProductShort{{ID: 5, Name: "X1 Extreme"}, {ID: 1, Name: "X1 Carbon"}}},
+		}},
+	}},
+}
+
+func TestFlattenProducts(t *testing.T) {
+	var ids []PID
+	for _, p := range FlattenProducts(testLineup) {
+		ids = append(ids, p.ID)
+	}
+	require.Equal(t, []PID{1, 2, 3, 4, 5}, ids)
+	require.Empty(t, FlattenProducts(nil))
+}
+
+func TestFlattenSeries(t *testing.T) {
+	series := FlattenSeries(testLineup)
+	require.Equal(t, []Series{
+		{Name: "ThinkPad X1", Products: []ProductShort{{ID: 1, Name: "X1 Carbon"}, {ID: 2, Name: "X1 Yoga"}, {ID: 5, Name: "X1 Extreme"}}},
+		{Name: "ThinkPad T", Products: []ProductShort{{ID: 3, Name: "T14"}}},
+		{Name: "ThinkPad P", Products: []ProductShort{{ID: 4, Name: "P1"}}},
+	}, series)
+	require.Len(t, testLineup[0].Lineup[0].Series[0].Products, 2)
+}
+
+func TestFindProductPath(t *testing.T) {
+	typ, line, series, ok := FindProductPath(testLineup, 4)
+	require.True(t, ok)
+	require.Equal(t, []string{"Workstations", "ThinkPad", "ThinkPad P"}, []string{typ, line, series})
+
+	typ, _, series, ok = FindProductPath(testLineup, 1)
+	require.True(t, ok)
+	require.Equal(t, "Laptops", typ)
+	require.Equal(t, "ThinkPad X1", series)
+
+	_, _, _, ok = FindProductPath(testLineup, 42)
+	require.False(t, ok)
+}
+
+func TestClassification(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		exp  Classification
+	}{
+		{"ThinkPad", ClassificationThinkPad},
+		{"legion ", ClassificationLegion},
+		{"LOQ", ClassificationLOQ},
+		{"Smart Devices", ClassificationUnknown},
+	} {
+		p := ProductType{Name: c.name}
+		require.Equal(t, c.exp, p.Classification(), c.name)
+		require.Equal(t, c.exp != ClassificationUnknown, p.Classification().Known())
+	}
+	require.False(t, Classification("thinkpad").Known())
+}
@@ -0,0 +1,103 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// CorrelationHeader is the HTTP header used to send the correlation ID. See WithCorrelationKey.
+const CorrelationHeader = "X-Request-ID"
+
+type attemptKey struct{}
+
+// withAttempt records the attempt number of a request in the context.
+func withAttempt(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, n)
+}
+
+// AttemptFromContext returns the attempt number of a request, starting from 1.
+// Retries have numbers greater than 1.
+//
+// The number is recorded in the context of each HTTP request sent by the client, which allows custom transports
+// (see WithTransport) to distinguish the initial requests from retries. It returns 1 for other contexts.
+func AttemptFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+	return 1
+}
+
+// correlationID returns the correlation ID from the context, or an empty string if it is not set.
+// See WithCorrelationKey.
+func (c *Client) correlationID(ctx context.Context) string {
+	if c.corrKey == nil {
+		return ""
+	}
+	switch v := ctx.Value(c.corrKey).(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	return ""
+}
+
+// logRequest logs a completed request attempt, if the client has a logger. See WithLogger.
+func (c *Client) logRequest(ctx context.Context, url string, status int, start time.Time, n int64, err error) {
+	if c.logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("method", "GET"),
+		slog.String("url", url),
+		slog.Int("attempt", AttemptFromContext(ctx)),
+		slog.Duration("duration", c.now().Sub(start)),
+		slog.Int64("bytes", n),
+	}
+	if id := c.correlationID(ctx); id != "" {
+		attrs = append(attrs, slog.String("correlation_id", id))
+	}
+	if status != 0 {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+	if err != nil && err != ErrNotFound {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, level, "psref request", attrs...)
+}
+
+// loggedBody wraps a response body to log the request when the body is closed.
+type loggedBody struct {
+	io.ReadCloser
+	c      *Client
+	ctx    context.Context
+	url    string
+	start  time.Time
+	n      int64
+	err    error
+	closed bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.c.logRequest(b.ctx, b.url, http.StatusOK, b.start, b.n, b.err)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package psref
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogs(t testing.TB, buf *bytes.Buffer) []map[string]interface{} {
+	var out []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var m map[string]interface{}
+		require.NoError(t, dec.Decode(&m))
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestLogger(t *testing.T) {
+	const body = `[{"BookTitle":"Book"}]`
+	tries := 0
+	handler := func(req *http.Request) (*http.Response, error) {
+		tries++
+		if tries%2 == 1 {
+			return newResponse(req, http.StatusServiceUnavailable, ""), nil
+		}
+		return newResponse(req, http.StatusOK, body), nil
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := newTransportClient(handler, WithLogger(logger), WithRetry(2), WithBackoff(0, 0))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+
+	logs := decodeLogs(t, &buf)
+	require.Len(t, logs, 2)
+	require.Equal(t, "WARN", logs[0]["level"])
+	require.Equal(t, float64(1), logs[0]["attempt"])
+	require.Equal(t, float64(http.StatusServiceUnavailable), logs[0]["status"])
+	require.Equal(t, "DEBUG", logs[1]["level"])
+	require.Equal(t, float64(2), logs[1]["attempt"])
+	require.Equal(t, float64(http.StatusOK), logs[1]["status"])
+	require.Equal(t, float64(len(body)), logs[1]["bytes"])
+	require.NotContains(t, logs[1], "body")
+
+	c = newTransportClient(handler, WithLogger(logger), WithLogBody(true), WithRetry(2), WithBackoff(0, 0))
+	_, err = c.Books(context.Background())
+	require.NoError(t, err)
+
+	logs = decodeLogs(t, &buf)
+	require.Len(t, logs, 3)
+	require.Contains(t, logs[1]["body"], "BookTitle")
+}
+
+func TestAttemptFromContext(t *testing.T) {
+	require.Equal(t, 1, AttemptFromContext(context.Background()))
+
+	var attempts []int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		attempts = append(attempts, AttemptFromContext(req.Context()))
+		if len(attempts) < 3 {
+			return newResponse(req, http.StatusServiceUnavailable, ""), nil
+		}
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRetry(3), WithBackoff(0, 0))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, attempts)
+}
+
+func TestCorrelationKey(t *testing.T) {
+	type reqIDKey struct{}
+	var ids []string
+	handler := func(req *http.Request) (*http.Response, error) {
+		ids = append(ids, req.Header.Get(CorrelationHeader))
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := newTransportClient(handler, WithLogger(logger), WithCorrelationKey(reqIDKey{}))
+	ctx := context.WithValue(context.Background(), reqIDKey{}, "req-1")
+	_, err := c.Books(ctx)
+	require.NoError(t, err)
+	_, err = c.Books(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"req-1", ""}, ids)
+
+	logs := decodeLogs(t, &buf)
+	require.Len(t, logs, 2)
+	require.Equal(t, "req-1", logs[0]["correlation_id"])
+	require.NotContains(t, logs[1], "correlation_id")
+
+	ids = nil
+	c = newTransportClient(handler)
+	_, err = c.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, ids)
+}
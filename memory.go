@@ -0,0 +1,114 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MemoryModule is a single memory configuration entry, e.g. soldered memory or a set of identical DIMMs.
+type MemoryModule struct {
+	SizeGB   int    // size of a single module
+	Count    int    // number of identical modules
+	Type     string // e.g. "DDR4", "LPDDR5"
+	SpeedMHz int
+	Soldered bool
+}
+
+// MemorySpec is a parsed memory specification. See Model.Memory.
+type MemorySpec struct {
+	Raw     string
+	Modules []MemoryModule
+	TotalGB int
+
+	// Type, SpeedMHz and Soldered summarize all modules.
+	Type      string
+	SpeedMHz  int
+	Soldered  bool
+	SlotCount int // number of memory slots, if specified
+
+	// Warnings lists parts of the specification that were ambiguous or could not be parsed.
+	Warnings []string
+}
+
+var (
+	reMemSize  = regexp.MustCompile(`(?i)(?:(\d+)\s*x\s*)?(\d+)\s*GB`)
+	reMemType  = regexp.MustCompile(`(?i)\b((?:LP)?DDR\d+X?)(?:-(\d+))?`)
+	reMemSlots = regexp.MustCompile(`(?i)\b(\d+|one|two|four)\s+(?:[\w-]+\s+){0,2}slots?\b`)
+)
+
+var memSlotWords = map[string]int{"one": 1, "two": 2, "four": 4}
+
+// Memory parses the memory specification of the model.
+//
+// Mixed configurations like "8GB Soldered + 8GB SO-DIMM DDR4-3200" are returned as multiple modules.
+// An error is returned only when nothing can be parsed; partial results are described in MemorySpec.Warnings.
+func (m *Model) Memory() (*MemorySpec, error) {
+	s, err := m.detail("Memory")
+	if err != nil {
+		return nil, err
+	}
+	return parseMemory(s)
+}
+
+func parseMemory(s string) (*MemorySpec, error) {
+	spec := &MemorySpec{Raw: s}
+	if sub := reMemSlots.FindStringSubmatch(s); sub != nil {
+		if n, ok := memSlotWords[strings.ToLower(sub[1])]; ok {
+			spec.SlotCount = n
+		} else {
+			spec.SlotCount, _ = strconv.Atoi(sub[1])
+		}
+	}
+	// "Up to 64GB (2x 32GB ...)" describes the maximal configuration, use the part in parentheses
+	if strings.HasPrefix(strings.ToLower(s), "up to") {
+		if i := strings.IndexByte(s, '('); i > 0 {
+			s = strings.TrimSuffix(s[i+1:], ")")
+		}
+	}
+	for _, part := range strings.Split(s, "+") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var mod MemoryModule
+		if sub := reMemSize.FindStringSubmatch(part); sub != nil {
+			mod.Count = 1
+			if sub[1] != "" {
+				mod.Count, _ = strconv.Atoi(sub[1])
+			}
+			mod.SizeGB, _ = strconv.Atoi(sub[2])
+		}
+		if sub := reMemType.FindStringSubmatch(part); sub != nil {
+			mod.Type = strings.ToUpper(sub[1])
+			mod.SpeedMHz, _ = strconv.Atoi(sub[2])
+		}
+		mod.Soldered = strings.Contains(strings.ToLower(part), "soldered")
+		if mod.SizeGB == 0 && mod.Type == "" {
+			spec.Warnings = append(spec.Warnings, fmt.Sprintf("unrecognized memory entry: %q", part))
+			continue
+		}
+		spec.Modules = append(spec.Modules, mod)
+	}
+	if len(spec.Modules) == 0 {
+		return nil, fmt.Errorf("unrecognized memory format: %q", spec.Raw)
+	}
+	for _, mod := range spec.Modules {
+		spec.TotalGB += mod.SizeGB * mod.Count
+		spec.Soldered = spec.Soldered || mod.Soldered
+		if spec.Type == "" {
+			spec.Type, spec.SpeedMHz = mod.Type, mod.SpeedMHz
+		} else if mod.Type != "" && mod.Type != spec.Type {
+			spec.Warnings = append(spec.Warnings, fmt.Sprintf("mixed memory types: %s and %s", spec.Type, mod.Type))
+		}
+	}
+	// type is usually listed only once for all modules
+	for i := range spec.Modules {
+		mod := &spec.Modules[i]
+		if mod.Type == "" && spec.Type != "" {
+			mod.Type, mod.SpeedMHz = spec.Type, spec.SpeedMHz
+		}
+	}
+	return spec, nil
+}
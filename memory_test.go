@@ -0,0 +1,70 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesMemory = []struct {
+	name string
+	in   string
+	exp  MemorySpec
+}{
+	{
+		name: "soldered",
+		in:   "16GB Soldered LPDDR5-5200",
+		exp: MemorySpec{
+			Modules: []MemoryModule{
+				{SizeGB: 16, Count: 1, Type: "LPDDR5", SpeedMHz: 5200, Soldered: true},
+			},
+			TotalGB: 16, Type: "LPDDR5", SpeedMHz: 5200, Soldered: true,
+		},
+	},
+	{
+		name: "mixed",
+		in:   "8GB Soldered + 8GB SO-DIMM DDR4-3200",
+		exp: MemorySpec{
+			Modules: []MemoryModule{
+				{SizeGB: 8, Count: 1, Type: "DDR4", SpeedMHz: 3200, Soldered: true},
+				{SizeGB: 8, Count: 1, Type: "DDR4", SpeedMHz: 3200},
+			},
+			TotalGB: 16, Type: "DDR4", SpeedMHz: 3200, Soldered: true,
+		},
+	},
+	{
+		name: "dimms",
+		in:   "2x 16GB SO-DIMM DDR5-4800, two DDR5 SO-DIMM slots",
+		exp: MemorySpec{
+			Modules: []MemoryModule{
+				{SizeGB: 16, Count: 2, Type: "DDR5", SpeedMHz: 4800},
+			},
+			TotalGB: 32, Type: "DDR5", SpeedMHz: 4800, SlotCount: 2,
+		},
+	},
+	{
+		name: "partial",
+		in:   "8GB SO-DIMM + unknown",
+		exp: MemorySpec{
+			Modules: []MemoryModule{
+				{SizeGB: 8, Count: 1},
+			},
+			TotalGB:  8,
+			Warnings: []string{`unrecognized memory entry: "unknown"`},
+		},
+	},
+}
+
+func TestParseMemory(t *testing.T) {
+	for _, c := range casesMemory {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseMemory(c.in)
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *got)
+		})
+	}
+	_, err := parseMemory("None")
+	require.Error(t, err)
+}
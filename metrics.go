@@ -0,0 +1,64 @@
+package psref
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of API requests. See WithMetrics.
+//
+// It allows exporting metrics to Prometheus or similar systems without depending on them directly.
+// For example, a Prometheus adapter can observe a histogram vector labeled by endpoint and status,
+// and increment a counter vector labeled by endpoint. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called for each completed request attempt, including retries.
+	//
+	// The endpoint is an API path with IDs replaced by placeholders, e.g. "/psref/mobile/product/{id}".
+	// The status is the HTTP status code of the response, or zero if the request failed for other reasons,
+	// e.g. a network error or a malformed response.
+	// The duration includes reading the response body.
+	ObserveRequest(endpoint string, status int, d time.Duration)
+	// IncRetry is called before each retry of a request to a given endpoint.
+	IncRetry(endpoint string)
+}
+
+// WithMetrics sets a collector for request metrics. See Metrics.
+func WithMetrics(m Metrics) ClientOption {
+	return clientOptionFunc(func(c *Client) {
+		c.metrics = m
+	})
+}
+
+// metricsEndpoint returns a low-cardinality endpoint name for an API path.
+//
+// Numeric path elements are replaced with "{id}", and an element following the ID with "{code}".
+func metricsEndpoint(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		switch {
+		case p != "" && strings.Trim(p, "0123456789") == "":
+			parts[i] = "{id}"
+		case i > 0 && parts[i-1] == "{id}" && p != "":
+			parts[i] = "{code}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// metricsStatus returns the HTTP status code for a request error. See Metrics.ObserveRequest.
+func metricsStatus(err error) int {
+	var se *StatusError
+	switch err = unwrapNoRetry(err); {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotModified):
+		return http.StatusNotModified
+	case errors.As(err, &se):
+		return se.StatusCode
+	}
+	return 0
+}
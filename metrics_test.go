@@ -0,0 +1,68 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	mu       sync.Mutex
+	requests []string
+	retries  []string
+}
+
+func (m *testMetrics) ObserveRequest(endpoint string, status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, endpoint+" "+http.StatusText(status))
+}
+
+func (m *testMetrics) IncRetry(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, endpoint)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	for path, exp := range map[string]string{
+		"/":                                   "/",
+		"/psref/mobile/searchv3":              "/psref/mobile/searchv3",
+		"/psref/mobile/product/1972":          "/psref/mobile/product/{id}",
+		"/psref/mobile/Model/1972/21CB000AUS": "/psref/mobile/Model/{id}/{code}",
+	} {
+		require.Equal(t, exp, metricsEndpoint(path), path)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var tries int
+	m := &testMetrics{}
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/psref/mobile/book" {
+			tries++
+			if tries == 1 {
+				return newResponse(req, http.StatusServiceUnavailable, ""), nil
+			}
+			return newResponse(req, http.StatusOK, "[]"), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	}, WithRetry(2), WithBackoff(0, 0), WithMetrics(m))
+	ctx := context.Background()
+
+	_, err := c.Books(ctx)
+	require.NoError(t, err)
+	_, err = c.ProductByID(ctx, 1972)
+	require.Equal(t, ErrNotFound, err)
+
+	require.Equal(t, []string{
+		"/psref/mobile/book Service Unavailable",
+		"/psref/mobile/book OK",
+		"/psref/mobile/product/{id} Not Found",
+	}, m.requests)
+	require.Equal(t, []string{"/psref/mobile/book"}, m.retries)
+}
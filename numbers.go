@@ -0,0 +1,37 @@
+package psref
+
+import (
+	"strconv"
+	"strings"
+)
+
+// reNum matches a decimal number with either "." or "," used as a decimal separator.
+//
+// Some regional datasheets are localized, thus values like "1,7GHz" are expected in addition to "1.7GHz".
+const reNum = `\d+(?:[.,]\d+)?`
+
+// parseNumber parses a decimal number regardless of the separator style used in the datasheet.
+//
+// A single separator of either kind is treated as a decimal one, except for a comma followed by exactly
+// three digits (e.g. "2,048"), which is treated as a thousands separator. If both are present, the last one
+// is considered a decimal separator and the other one is treated as a thousands separator.
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	dot, comma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	switch {
+	case dot >= 0 && comma >= 0:
+		if comma > dot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case comma >= 0:
+		if strings.Count(s, ",") > 1 || len(s)-comma-1 == 3 {
+			s = strings.ReplaceAll(s, ",", "")
+		} else {
+			s = strings.Replace(s, ",", ".", 1)
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,36 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesNumber = []struct {
+	in  string
+	exp float64
+}{
+	{in: "17", exp: 17},
+	{in: "1.7", exp: 1.7},
+	{in: "1,7", exp: 1.7},
+	{in: " 15,36 ", exp: 15.36},
+	{in: "1,234.5", exp: 1234.5},
+	{in: "1.234,5", exp: 1234.5},
+	{in: "1,234,567", exp: 1234567},
+	{in: "1,000", exp: 1000},
+	{in: "2,048", exp: 2048},
+	{in: "1,0000", exp: 1},
+}
+
+func TestParseNumber(t *testing.T) {
+	for _, c := range casesNumber {
+		c := c
+		t.Run(c.in, func(t *testing.T) {
+			got, err := parseNumber(c.in)
+			require.NoError(t, err)
+			require.InDelta(t, c.exp, got, 1e-9)
+		})
+	}
+	_, err := parseNumber("1.2.3")
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package psref
+
+import (
+	"regexp"
+	"strings"
+)
+
+// OSFamily is a family of operating systems. See OSSpec.
+type OSFamily string
+
+const (
+	// OSUnknown is set when the operating system cannot be recognized.
+	OSUnknown = OSFamily("")
+	// OSNone is set for models shipped without a usable operating system, including the ones with DOS.
+	OSNone     = OSFamily("None")
+	OSWindows  = OSFamily("Windows")
+	OSLinux    = OSFamily("Linux")
+	OSChromeOS = OSFamily("ChromeOS")
+)
+
+// OSSpec is a parsed operating system specification. See Model.OperatingSystem.
+//
+// Fields that cannot be determined from the specification are left empty.
+type OSSpec struct {
+	Raw     string
+	Family  OSFamily
+	Edition string // e.g. "Pro", "Home in S mode", "Ubuntu Linux", "DOS"
+	Version string // e.g. "11", "22.04"
+	Bits    int    // 32 or 64, if specified
+}
+
+var (
+	reOSBits      = regexp.MustCompile(`(?i)\b(32|64)(?:[- ]?bit)?\b`)
+	reOSWinVer    = regexp.MustCompile(`(?i)^(\d+|XP|Vista)\b`)
+	reOSVersion   = regexp.MustCompile(`\b\d+(?:\.\d+)+\b|\b\d+\b`)
+	reOSLinux     = regexp.MustCompile(`(?i)\b(?:linux|ubuntu|fedora|red\s*hat|debian|suse)\b`)
+	reOSChrome    = regexp.MustCompile(`(?i)\bchrome\s*os\b`)
+	reOSNone      = regexp.MustCompile(`(?i)^(?:no\b|none\b|without\b)`)
+	reOSDOS       = regexp.MustCompile(`(?i)\b(?:free)?dos\b`)
+	reOSSpaces    = regexp.MustCompile(`\s{2,}`)
+	reOSWinPrefix = regexp.MustCompile(`(?i)^(?:microsoft\s+)?windows\s*`)
+)
+
+// OperatingSystem parses the operating system specification of the model.
+//
+// Unrecognized specifications are not considered an error, Family is set to OSUnknown in that case.
+// An error is returned only if the model has no operating system specification.
+func (m *Model) OperatingSystem() (*OSSpec, error) {
+	s, err := m.detail("Operating System")
+	if err != nil {
+		return nil, err
+	}
+	return parseOS(s), nil
+}
+
+func parseOS(s string) *OSSpec {
+	o := &OSSpec{Raw: s}
+	// other parts usually list languages or preloaded software
+	if i := strings.IndexAny(s, ",;"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	switch {
+	case reOSNone.MatchString(s):
+		o.Family = OSNone
+		return o
+	case reOSDOS.MatchString(s):
+		o.Family = OSNone
+		o.Edition = "DOS"
+		return o
+	}
+	if sub := reOSBits.FindAllStringSubmatchIndex(s, -1); len(sub) != 0 {
+		last := sub[len(sub)-1]
+		if s[last[2]:last[3]] == "64" {
+			o.Bits = 64
+		} else {
+			o.Bits = 32
+		}
+		s = strings.TrimSpace(s[:last[0]] + s[last[1]:])
+	}
+	switch {
+	case reOSWinPrefix.MatchString(s):
+		o.Family = OSWindows
+		s = reOSWinPrefix.ReplaceAllString(s, "")
+		if sub := reOSWinVer.FindString(s); sub != "" {
+			o.Version = sub
+			s = s[len(sub):]
+		}
+		o.Edition = cleanOSEdition(s)
+	case reOSChrome.MatchString(s):
+		o.Family = OSChromeOS
+		o.Edition = cleanOSEdition(reOSChrome.ReplaceAllString(s, ""))
+	case reOSLinux.MatchString(s):
+		o.Family = OSLinux
+		if loc := reOSVersion.FindStringIndex(s); loc != nil {
+			o.Version = s[loc[0]:loc[1]]
+			s = s[:loc[0]] + s[loc[1]:]
+		}
+		o.Edition = cleanOSEdition(s)
+	}
+	return o
+}
+
+func cleanOSEdition(s string) string {
+	return reOSSpaces.ReplaceAllString(strings.TrimSpace(s), " ")
+}
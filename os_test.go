@@ -0,0 +1,79 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesOS = []struct {
+	name string
+	in   string
+	exp  OSSpec
+}{
+	{
+		name: "windows pro",
+		in:   "Windows 11 Pro 64",
+		exp:  OSSpec{Family: OSWindows, Edition: "Pro", Version: "11", Bits: 64},
+	},
+	{
+		name: "windows languages",
+		in:   "Windows 10 Home 64, English",
+		exp:  OSSpec{Family: OSWindows, Edition: "Home", Version: "10", Bits: 64},
+	},
+	{
+		name: "s mode",
+		in:   "Windows 11 Home in S mode",
+		exp:  OSSpec{Family: OSWindows, Edition: "Home in S mode", Version: "11"},
+	},
+	{
+		name: "workstations",
+		in:   "Windows 11 Pro for Workstations 64-bit",
+		exp:  OSSpec{Family: OSWindows, Edition: "Pro for Workstations", Version: "11", Bits: 64},
+	},
+	{
+		name: "no os",
+		in:   "No Operating System",
+		exp:  OSSpec{Family: OSNone},
+	},
+	{
+		name: "dos",
+		in:   "DOS",
+		exp:  OSSpec{Family: OSNone, Edition: "DOS"},
+	},
+	{
+		name: "ubuntu",
+		in:   "Ubuntu Linux",
+		exp:  OSSpec{Family: OSLinux, Edition: "Ubuntu Linux"},
+	},
+	{
+		name: "ubuntu version",
+		in:   "Ubuntu 22.04 LTS",
+		exp:  OSSpec{Family: OSLinux, Edition: "Ubuntu LTS", Version: "22.04"},
+	},
+	{
+		name: "chrome",
+		in:   "Chrome OS",
+		exp:  OSSpec{Family: OSChromeOS},
+	},
+	{
+		name: "unknown",
+		in:   "Android 13",
+		exp:  OSSpec{},
+	},
+}
+
+func TestOperatingSystem(t *testing.T) {
+	for _, c := range casesOS {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{Detail: []KeyValue{{Name: "Operating System", Value: c.in}}}
+			got, err := m.OperatingSystem()
+			require.NoError(t, err)
+			c.exp.Raw = c.in
+			require.Equal(t, c.exp, *got)
+		})
+	}
+	_, err := (&Model{}).OperatingSystem()
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package psref
+
+import (
+	"strings"
+	"unicode"
+)
+
+// partNumberDetails lists specification names that might hold additional part numbers of the model.
+var partNumberDetails = []string{"Part Number", "Part Numbers", "MTM", "Machine Type Model"}
+
+// PartNumbers returns part numbers (MTMs) of the model. The model code is always listed first.
+//
+// PSREF usually lists only the model code, but some models have additional part numbers in the specification.
+// Only values that look like a valid model code are returned, see ModelCode.Valid. Duplicates are removed.
+func (m *Model) PartNumbers() []string {
+	var out []string
+	seen := make(map[string]struct{})
+	add := func(s string) {
+		s = strings.ToUpper(s)
+		if _, ok := seen[s]; ok || !ModelCode(s).Valid() {
+			return
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	add(string(m.Code))
+	for _, v := range m.Detail {
+		if !isPartNumberDetail(v.Name) {
+			continue
+		}
+		for _, s := range strings.FieldsFunc(v.Value, func(r rune) bool {
+			return r == ',' || r == ';' || r == '/' || unicode.IsSpace(r)
+		}) {
+			add(s)
+		}
+	}
+	return out
+}
+
+func isPartNumberDetail(name string) bool {
+	name = strings.TrimSpace(name)
+	for _, n := range partNumberDetails {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Availability returns the availability of the model.
+//
+// The "Availability" specification value is returned if the model has one. Otherwise, the result is
+// the withdrawn status of the model, or of its product if the model itself is active. See WithdrawnStatus.String.
+// PSREF does not expose prices or regional stock, thus neither is reflected here.
+func (m *Model) Availability() string {
+	if v := strings.TrimSpace(m.DetailByName("Availability")); v != "" {
+		return v
+	}
+	if s := WithdrawnStatus(m.WithdrawnStatus); s != StatusActive {
+		return s.String()
+	}
+	return WithdrawnStatus(m.Product.WithdrawnStatus).String()
+}
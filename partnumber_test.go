@@ -0,0 +1,31 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartNumbers(t *testing.T) {
+	m := &Model{Code: "21CB000AUS", Detail: []KeyValue{
+		{Name: "Processor", Value: "Intel Core i5-1240P"},
+		{Name: "Part Number", Value: "21cb000aus, 21CB000BUS / 21CB000CUS"},
+		{Name: "MTM", Value: "21CB000BUS; N/A"},
+	}}
+	require.Equal(t, []string{"21CB000AUS", "21CB000BUS", "21CB000CUS"}, m.PartNumbers())
+	require.Empty(t, (&Model{}).PartNumbers())
+}
+
+func TestAvailability(t *testing.T) {
+	m := &Model{}
+	require.Equal(t, "active", m.Availability())
+	m.WithdrawnStatus = int(StatusWithdrawn)
+	require.Equal(t, "withdrawn", m.Availability())
+	m.WithdrawnStatus = 7
+	require.Equal(t, "WithdrawnStatus(7)", m.Availability())
+	m.WithdrawnStatus = int(StatusActive)
+	m.Product.WithdrawnStatus = int64(StatusWithdrawn)
+	require.Equal(t, "withdrawn", m.Availability())
+	m.Detail = []KeyValue{{Name: "Availability", Value: "Limited"}}
+	require.Equal(t, "Limited", m.Availability())
+}
@@ -0,0 +1,142 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PortSpec is a parsed specification of a port or a group of identical ports. See Model.Ports.
+type PortSpec struct {
+	Raw         string // original specification of this port
+	Count       int    // number of identical ports
+	Type        string // e.g. "USB-C", "USB-A", "HDMI", "Ethernet"; empty if unknown
+	Version     string // e.g. "3.2 Gen 1", "2.0b", "4"
+	Thunderbolt bool   // port supports Thunderbolt
+}
+
+var (
+	rePortCount       = regexp.MustCompile(`(?i)^(\d+)\s*x\s*`)
+	rePortVersion     = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?[a-z]?(?:\s+Gen\s*\d(?:x\d)?)?)\b`)
+	rePortUSBHint     = regexp.MustCompile(`(?i)\bUSB\s*(\d+(?:\.\d+)?(?:\s+Gen\s*\d(?:x\d)?)?)`)
+	rePortThunderbolt = regexp.MustCompile(`(?i)\bThunderbolt\b`)
+)
+
+// portTypes lists recognized port types. The first matching entry is used, thus more specific patterns go first.
+var portTypes = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"Thunderbolt", regexp.MustCompile(`(?i)^Thunderbolt\b`)},
+	{"USB-C", regexp.MustCompile(`(?i)\bUSB[- ]?(?:Type[- ]?)?C\b|\bType[- ]?C\b`)},
+	{"USB-A", regexp.MustCompile(`(?i)\bUSB[- ]?(?:Type[- ]?)?A\b`)},
+	{"Mini DisplayPort", regexp.MustCompile(`(?i)\bMini[- ]?DisplayPort\b|\bmDP\b`)},
+	{"HDMI", regexp.MustCompile(`(?i)\bHDMI\b`)},
+	{"DisplayPort", regexp.MustCompile(`(?i)\bDisplayPort\b|\bDP\b`)},
+	{"USB", regexp.MustCompile(`(?i)\bUSB\b`)},
+	{"Ethernet", regexp.MustCompile(`(?i)\bEthernet\b|\bRJ-?45\b`)},
+	{"Audio jack", regexp.MustCompile(`(?i)\bheadphone\b|\bmicrophone\b|\baudio jack\b|\bcombo jack\b`)},
+	{"Card reader", regexp.MustCompile(`(?i)\bcard reader\b`)},
+	{"SIM", regexp.MustCompile(`(?i)\bSIM\b`)},
+	{"Docking", regexp.MustCompile(`(?i)\bdocking\b`)},
+	{"Power", regexp.MustCompile(`(?i)\bpower connector\b|\bDC-in\b`)},
+}
+
+// Ports parses the ports specification of the model, e.g. "2x USB-C (Thunderbolt 4), 2x USB-A 3.2 Gen 1, HDMI 2.0b".
+//
+// Each comma-separated entry that starts with a known port type returns a separate PortSpec.
+// Unrecognized entries are kept in the Raw field of the previous port.
+// An error is returned only if the specification is missing or none of the ports can be recognized.
+func (m *Model) Ports() ([]PortSpec, error) {
+	s, err := m.detail("Ports")
+	if err != nil {
+		return nil, err
+	}
+	return parsePorts(s)
+}
+
+func parsePorts(s string) ([]PortSpec, error) {
+	var out []PortSpec
+	known := false
+	for _, part := range splitPorts(s) {
+		p := parsePort(part)
+		if p.Type == "" && len(out) != 0 {
+			out[len(out)-1].Raw += ", " + part
+			continue
+		}
+		if p.Type != "" {
+			known = true
+		}
+		out = append(out, p)
+	}
+	if !known {
+		return nil, fmt.Errorf("unrecognized ports format: %q", s)
+	}
+	return out, nil
+}
+
+// splitPorts splits the specification on commas, semicolons and new lines, except for ones inside parentheses.
+func splitPorts(s string) []string {
+	var (
+		out   []string
+		depth int
+		last  int
+	)
+	add := func(part string) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',', ';', '\n':
+			if depth == 0 {
+				add(s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	add(s[last:])
+	return out
+}
+
+func parsePort(s string) PortSpec {
+	p := PortSpec{Raw: s, Count: 1}
+	rest := strings.NewReplacer("®", "", "™", "").Replace(s)
+	if sub := rePortCount.FindStringSubmatch(rest); sub != nil {
+		p.Count, _ = strconv.Atoi(sub[1])
+		rest = rest[len(sub[0]):]
+	}
+	main, hints := rest, ""
+	if i := strings.IndexByte(rest, '('); i >= 0 {
+		main, hints = rest[:i], rest[i:]
+	}
+	for _, t := range portTypes {
+		loc := t.re.FindStringIndex(main)
+		if loc == nil {
+			continue
+		}
+		p.Type = t.name
+		if sub := rePortVersion.FindStringSubmatch(main[loc[1]:]); sub != nil {
+			p.Version = sub[1]
+		}
+		break
+	}
+	if p.Type == "" {
+		return p
+	}
+	if p.Version == "" && strings.HasPrefix(p.Type, "USB") {
+		if sub := rePortUSBHint.FindStringSubmatch(hints); sub != nil {
+			p.Version = sub[1]
+		}
+	}
+	p.Thunderbolt = p.Type == "Thunderbolt" || rePortThunderbolt.MatchString(hints)
+	return p
+}
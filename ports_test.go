@@ -0,0 +1,54 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPorts = []struct {
+	name string
+	in   string
+	exp  []PortSpec
+}{
+	{
+		name: "laptop",
+		in:   "2x USB-C (Thunderbolt 4), 2x USB-A 3.2 Gen 1, HDMI 2.0b, Headphone / microphone combo jack (3.5mm)",
+		exp: []PortSpec{
+			{Raw: "2x USB-C (Thunderbolt 4)", Count: 2, Type: "USB-C", Thunderbolt: true},
+			{Raw: "2x USB-A 3.2 Gen 1", Count: 2, Type: "USB-A", Version: "3.2 Gen 1"},
+			{Raw: "HDMI 2.0b", Count: 1, Type: "HDMI", Version: "2.0b"},
+			{Raw: "Headphone / microphone combo jack (3.5mm)", Count: 1, Type: "Audio jack"},
+		},
+	},
+	{
+		name: "hints",
+		in:   "1x USB-C® (USB 3.2 Gen 2, support data transfer, Power Delivery 3.0 and DisplayPort™ 1.4); 1x Thunderbolt™ 4 (USB-C)\n1x Ethernet (RJ-45)",
+		exp: []PortSpec{
+			{Raw: "1x USB-C® (USB 3.2 Gen 2, support data transfer, Power Delivery 3.0 and DisplayPort™ 1.4)", Count: 1, Type: "USB-C", Version: "3.2 Gen 2"},
+			{Raw: "1x Thunderbolt™ 4 (USB-C)", Count: 1, Type: "Thunderbolt", Version: "4", Thunderbolt: true},
+			{Raw: "1x Ethernet (RJ-45)", Count: 1, Type: "Ethernet"},
+		},
+	},
+	{
+		name: "unknown",
+		in:   "USB 2.0, always on, Kensington Nano Security Slot, microSD card reader",
+		exp: []PortSpec{
+			{Raw: "USB 2.0, always on, Kensington Nano Security Slot", Count: 1, Type: "USB", Version: "2.0"},
+			{Raw: "microSD card reader", Count: 1, Type: "Card reader"},
+		},
+	},
+}
+
+func TestParsePorts(t *testing.T) {
+	for _, c := range casesPorts {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parsePorts(c.in)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, got)
+		})
+	}
+	_, err := parsePorts("None")
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+// Package psreftest implements a fake PSREF API server for tests.
+//
+// The server responds with canned data embedded into the package, thus it doesn't require network access.
+package psreftest
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dennwc/psref"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Known entries available in the fixtures.
+const (
+	ProductID = psref.PID(1972)
+	ModelCode = psref.ModelCode("21CB000AUS")
+)
+
+// Handler returns an HTTP handler which serves PSREF API responses from embedded fixtures.
+//
+// Unknown products and models result in a not found response, while unknown search queries return no results.
+// Search is served on any "/psref/mobile/search*" path, regardless of the endpoint version.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", serveFile("products.json"))
+	mux.HandleFunc("GET /psref/mobile/withdrawproducts", serveFile("withdrawn.json"))
+	mux.HandleFunc("GET /psref/mobile/new", serveFile("updates.json"))
+	mux.HandleFunc("GET /psref/mobile/book", serveFile("books.json"))
+	mux.HandleFunc("GET /psref/mobile/product/{id}", serveProduct)
+	mux.HandleFunc("GET /psref/mobile/Model/{id}/{code}", func(w http.ResponseWriter, r *http.Request) {
+		writeFile(w, r, path.Join("model", r.PathValue("id")+"_"+r.PathValue("code")+".json"))
+	})
+	// match any version of the search endpoint, so clients using psref.WithSearchPath are served as well
+	mux.HandleFunc("GET /psref/mobile/{search}", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.PathValue("search"), "search") {
+			http.NotFound(w, r)
+			return
+		}
+		name := path.Join("search", strings.ToUpper(r.URL.Query().Get("kw"))+".json")
+		if _, err := fs.Stat(fixtures, path.Join("fixtures", name)); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"result":[],"total":0}`))
+			return
+		}
+		writeFile(w, r, name)
+	})
+	return mux
+}
+
+// NewServer starts a fake PSREF API server and returns a client configured to use it.
+//
+// Rate limiting and retries are disabled by default, but can be changed with opts.
+// The caller must close the server when it's no longer needed.
+func NewServer(opts ...psref.ClientOption) (*httptest.Server, *psref.Client) {
+	srv := httptest.NewServer(Handler())
+	opts = append([]psref.ClientOption{
+		psref.WithBaseURL(srv.URL),
+		psref.WithRetry(1),
+		psref.WithRate(nil),
+	}, opts...)
+	return srv, psref.NewClient(opts...)
+}
+
+func serveFile(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeFile(w, r, name)
+	}
+}
+
+func writeFile(w http.ResponseWriter, r *http.Request, name string) {
+	data, err := fixtures.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// serveProduct serves a product fixture. Fixtures contain a single page of models,
+// thus requests for any other page return a product with no models.
+func serveProduct(w http.ResponseWriter, r *http.Request) {
+	name := path.Join("product", r.PathValue("id")+".json")
+	page, _ := strconv.Atoi(r.URL.Query().Get("pagenumber"))
+	if page <= 1 {
+		writeFile(w, r, name)
+		return
+	}
+	data, err := fixtures.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var p map[string]interface{}
+	if err := json.Unmarshal(data, &p); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p["Models"] = []interface{}{}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(p)
+}
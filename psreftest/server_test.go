@@ -0,0 +1,81 @@
+package psreftest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennwc/psref"
+	"github.com/dennwc/psref/psreftest"
+)
+
+func TestServer(t *testing.T) {
+	srv, c := psreftest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	types, err := c.Products(ctx)
+	require.NoError(t, err)
+	require.Len(t, types, 2)
+	require.Equal(t, "https://psref.lenovo.com/syspool/Sys/Image/ThinkPad/ThinkPad_X1_Carbon_Gen_10/ThinkPad_X1_Carbon_Gen_10_CT1_01.png", types[0].Lineup[0].Image)
+
+	types, err = c.WithdrawnProducts(ctx)
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+
+	upd, err := c.Updates(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(593), upd.Version)
+	require.Equal(t, psref.ReasonSpecUpdated, upd.Updated[0].Reason)
+
+	books, err := c.Books(ctx)
+	require.NoError(t, err)
+	require.Len(t, books, 3)
+
+	p, err := c.ProductByID(ctx, psreftest.ProductID)
+	require.NoError(t, err)
+	require.Equal(t, "ThinkPad_X1_Carbon_Gen_10", p.Key)
+	require.Len(t, p.Models, 3)
+
+	var n int
+	for _, err := range c.AllProductModels(ctx, psreftest.ProductID) {
+		require.NoError(t, err)
+		n++
+	}
+	require.Equal(t, 3, n)
+
+	docs, err := c.ProductDocs(ctx, psreftest.ProductID)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "https://pcsupport.lenovo.com/docs/x1_carbon_gen10_ug.pdf", docs[0].URL)
+
+	_, err = c.ProductByID(ctx, 1)
+	require.Equal(t, psref.ErrNotFound, err)
+
+	m, err := c.ModelByCode(ctx, psreftest.ModelCode)
+	require.NoError(t, err)
+	require.Equal(t, psreftest.ProductID, m.ID)
+	require.Equal(t, "16GB Soldered LPDDR5-5200", m.DetailByName("Memory"))
+
+	_, err = c.ModelByCode(ctx, "UNKNOWN")
+	require.Equal(t, psref.ErrNotFound, err)
+
+	res, total, err := c.SearchPage(ctx, "X1", 1)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, 2, total)
+}
+
+func TestServerSearchPath(t *testing.T) {
+	for _, path := range []string{"/psref/mobile/searchv3", "/psref/mobile/searchv4"} {
+		t.Run(path, func(t *testing.T) {
+			srv, c := psreftest.NewServer(psref.WithSearchPath(path))
+			defer srv.Close()
+
+			m, err := c.ModelByCode(context.Background(), psreftest.ModelCode)
+			require.NoError(t, err)
+			require.Equal(t, psreftest.ProductID, m.ID)
+		})
+	}
+}
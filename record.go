@@ -0,0 +1,133 @@
+package psref
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRecorded is returned by a replay client for requests that have no recorded response. See NewReplayClient.
+var ErrNotRecorded = errors.New("response not recorded")
+
+const (
+	recordExt         = ".json"
+	recordNotFoundExt = ".notfound"
+)
+
+// WithRecorder saves API responses to files in a given directory, so they can be replayed later with NewReplayClient.
+// This is useful for reproducing decoding issues.
+//
+// Files are named by a hash of the request path and query, so the same request always maps to the same file.
+// Successful responses are saved as-is, while not found responses are saved as empty marker files.
+// Other responses and downloads are not recorded. A failure to save the response fails the request.
+//
+// The recorder wraps a copy of the HTTP client transport.
+func WithRecorder(dir string) ClientOption {
+	return clientOptionFunc(func(c *Client) {
+		c.recordDir = dir
+	})
+}
+
+// NewReplayClient creates a client that serves responses recorded with WithRecorder from a given directory,
+// without sending any requests. Requests that were not recorded fail with ErrNotRecorded.
+//
+// The client has no rate limit and does not retry requests by default. Additional options are applied as usual.
+func NewReplayClient(dir string, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{
+		WithRate(nil),
+		WithRetry(1),
+	}, opts...)
+	opts = append(opts, WithHTTPClient(&http.Client{Transport: &replayTransport{dir: dir}}))
+	return NewClient(opts...)
+}
+
+// recordName returns the base name of the file with a recorded response for a given request.
+func recordName(req *http.Request) string {
+	h := sha256.Sum256([]byte(req.URL.RequestURI()))
+	return hex.EncodeToString(h[:16])
+}
+
+// recorder is an HTTP transport that saves API responses to files. See WithRecorder.
+type recorder struct {
+	rt   http.RoundTripper
+	dir  string
+	base string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.rt.RoundTrip(req)
+	if err != nil || !strings.HasPrefix(req.URL.String(), r.base) {
+		return resp, err
+	}
+	name := filepath.Join(r.dir, recordName(req))
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if err = r.save(name+recordExt, data); err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+	case http.StatusNotFound:
+		if err = r.save(name+recordNotFoundExt, nil); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// CloseIdleConnections closes idle connections of the underlying transport. See Client.Close.
+func (r *recorder) CloseIdleConnections() {
+	if c, ok := r.rt.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (r *recorder) save(name string, data []byte) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0644)
+}
+
+// replayTransport is an HTTP transport that serves responses saved by recorder. See NewReplayClient.
+type replayTransport struct {
+	dir string
+}
+
+func (r *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := filepath.Join(r.dir, recordName(req))
+	code := http.StatusOK
+	data, err := os.ReadFile(name + recordExt)
+	if os.IsNotExist(err) {
+		code = http.StatusNotFound
+		_, err = os.Stat(name + recordNotFoundExt)
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, req.URL.RequestURI())
+	} else if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    code,
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
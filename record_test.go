@@ -0,0 +1,55 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/psref/mobile/book":
+			_, _ = w.Write([]byte(`[{"BookTitle": "ThinkPad"}]`))
+		case "/psref/mobile/product/1972":
+			_, _ = w.Write([]byte(`{"ProductId": 1972, "ProductKey": "ThinkPad_X1_Carbon_Gen_10"}`))
+		case "/psref/mobile/product/1":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithRecorder(dir))
+	ctx := context.Background()
+
+	books, err := c.Books(ctx)
+	require.NoError(t, err)
+	p, err := c.ProductByID(ctx, 1972)
+	require.NoError(t, err)
+	_, err = c.ProductByID(ctx, 2)
+	require.Equal(t, ErrNotFound, err)
+	_, err = c.ProductByID(ctx, 1)
+	require.Error(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	r := NewReplayClient(dir)
+	books2, err := r.Books(ctx)
+	require.NoError(t, err)
+	require.Equal(t, books, books2)
+	p2, err := r.ProductByID(ctx, 1972)
+	require.NoError(t, err)
+	require.Equal(t, p, p2)
+	_, err = r.ProductByID(ctx, 2)
+	require.Equal(t, ErrNotFound, err)
+	_, err = r.ProductByID(ctx, 1)
+	require.True(t, errors.Is(err, ErrNotRecorded))
+	require.NoError(t, r.Close())
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,87 @@
+package psref
+
+import (
+	"context"
+	"time"
+)
+
+// catalog is an in-memory copy of the product catalog. See Client.StartAutoRefresh.
+type catalog struct {
+	products []ProductType
+	updates  *Updates
+}
+
+// StartAutoRefresh starts a background loop that fetches Products and Updates immediately and then with a given interval.
+// The results are kept in memory and returned by CachedProducts and CachedUpdates without network requests.
+//
+// Product list is fetched again only if the data version reported by Updates has changed.
+// Note that responses may still come from the response cache, see WithCache.
+//
+// If the interval is not positive, the catalog is refreshed every 10 minutes, same as in WithVersionedCache.
+//
+// Refresh errors are sent to the returned channel and do not stop the loop. Errors are dropped if the channel is not read.
+// The loop stops and the channel is closed when the context is cancelled.
+func (c *Client) StartAutoRefresh(ctx context.Context, interval time.Duration) <-chan error {
+	if interval <= 0 {
+		interval = apiDefaultVersionCheck
+	}
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := c.refreshCatalog(ctx); err != nil && ctx.Err() == nil {
+				select {
+				case errc <- err:
+				default:
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return errc
+}
+
+// refreshCatalog fetches the latest product catalog and stores it in memory. See StartAutoRefresh.
+func (c *Client) refreshCatalog(ctx context.Context) error {
+	upd, err := c.Updates(ctx)
+	if err != nil {
+		return err
+	}
+	cur := c.catalog.Load()
+	if cur != nil && cur.updates != nil && upd.HasVersion() && cur.updates.Version == upd.Version {
+		c.catalog.Store(&catalog{products: cur.products, updates: upd})
+		return nil
+	}
+	products, err := c.Products(ctx)
+	if err != nil {
+		return err
+	}
+	c.catalog.Store(&catalog{products: products, updates: upd})
+	return nil
+}
+
+// CachedProducts returns the product list fetched by the auto-refresh loop, or nil if it was not fetched yet.
+// See StartAutoRefresh.
+//
+// The returned slice is shared and must not be modified.
+func (c *Client) CachedProducts() []ProductType {
+	if cur := c.catalog.Load(); cur != nil {
+		return cur.products
+	}
+	return nil
+}
+
+// CachedUpdates returns the recent updates fetched by the auto-refresh loop, or nil if they were not fetched yet.
+// See StartAutoRefresh.
+func (c *Client) CachedUpdates() *Updates {
+	if cur := c.catalog.Load(); cur != nil {
+		return cur.updates
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRefresh(t *testing.T) {
+	var (
+		version  atomic.Int32
+		products atomic.Int32
+		fail     atomic.Bool
+	)
+	version.Store(1)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/psref/mobile/new":
+			if fail.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, `{"LatestUpdateVersion": "<b>Version %d - Oct.5, 2022</b>"}`, version.Load())
+		case "/":
+			n := products.Add(1)
+			fmt.Fprintf(w, `[{"ClassificationName": "ThinkPad %d"}]`, n)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c := newMockClient(t, h)
+	require.Nil(t, c.CachedProducts())
+	require.Nil(t, c.CachedUpdates())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errc := c.StartAutoRefresh(ctx, 20*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return c.CachedProducts() != nil
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, "ThinkPad 1", c.CachedProducts()[0].Name)
+	require.Equal(t, uint64(1), c.CachedUpdates().Version)
+
+	// same version, product list is not fetched again
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(1), products.Load())
+
+	version.Store(2)
+	require.Eventually(t, func() bool {
+		return c.CachedUpdates().Version == 2
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, "ThinkPad 2", c.CachedProducts()[0].Name)
+
+	fail.Store(true)
+	select {
+	case err := <-errc:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected an error")
+	}
+	// the catalog is still available
+	require.Equal(t, "ThinkPad 2", c.CachedProducts()[0].Name)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-errc:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestAutoRefreshDefaults(t *testing.T) {
+	var products, updates atomic.Int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/psref/mobile/new":
+			updates.Add(1)
+			fmt.Fprint(w, `{"LatestUpdateVersion": "<b>Version 1 - Oct.5, 2022</b>"}`)
+		case "/":
+			products.Add(1)
+			fmt.Fprint(w, `[{"ClassificationName": "ThinkPad"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c := newMockClient(t, h)
+	// catalog without updates must not break the version check
+	c.catalog.Store(&catalog{products: []ProductType{{Name: "Old"}}})
+	require.NoError(t, c.refreshCatalog(context.Background()))
+	require.Equal(t, "ThinkPad", c.CachedProducts()[0].Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errc := c.StartAutoRefresh(ctx, 0)
+	require.Eventually(t, func() bool {
+		return updates.Load() == 2
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, int32(1), products.Load())
+	cancel()
+	for range errc {
+	}
+}
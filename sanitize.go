@@ -0,0 +1,78 @@
+package psref
+
+import (
+	"strings"
+	"unicode"
+)
+
+// postProcessor is implemented by API types that are fixed up after decoding. See Client.postProcess.
+type postProcessor interface {
+	Normalizer
+	sanitize()
+}
+
+// postProcess normalizes URLs and sanitizes strings of a decoded response, according to the client settings.
+// See WithRawImageURLs and WithStringSanitization.
+func (c *Client) postProcess(v postProcessor) {
+	if !c.rawURLs {
+		v.Normalize()
+	}
+	if c.sanitize {
+		v.sanitize()
+	}
+}
+
+// sanitizeString removes control and formatting characters, collapses whitespace into a single space and trims the string.
+func sanitizeString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r), r == unicode.ReplacementChar:
+			continue
+		}
+		if space && b.Len() != 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (p *ProductType) sanitize() {
+	p.Name = sanitizeString(p.Name)
+	for i := range p.Lineup {
+		l := &p.Lineup[i]
+		l.Name = sanitizeString(l.Name)
+		for j := range l.Series {
+			s := &l.Series[j]
+			s.Name = sanitizeString(s.Name)
+			for k := range s.Products {
+				s.Products[k].Name = sanitizeString(s.Products[k].Name)
+			}
+		}
+	}
+}
+
+func (p *Product) sanitize() {
+	p.Name = sanitizeString(p.Name)
+	for i := range p.Models {
+		p.Models[i].Summary = sanitizeString(p.Models[i].Summary)
+	}
+	for i := range p.Docs {
+		p.Docs[i].Title = sanitizeString(p.Docs[i].Title)
+	}
+}
+
+func (m *Model) sanitize() {
+	m.Product.sanitize()
+	for i := range m.Detail {
+		m.Detail[i].Name = sanitizeString(m.Detail[i].Name)
+		m.Detail[i].Value = sanitizeString(m.Detail[i].Value)
+	}
+}
@@ -0,0 +1,60 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSanitize = []struct {
+	name string
+	in   string
+	exp  string
+}{
+	{name: "clean", in: "Intel Core i7-1260P", exp: "Intel Core i7-1260P"},
+	{name: "trim", in: "  16GB \t", exp: "16GB"},
+	{name: "null", in: "16GB\u0000 DDR5", exp: "16GB DDR5"},
+	{name: "nbsp", in: "14\"\u00a0FHD \u00a0IPS", exp: "14\" FHD IPS"},
+	{name: "newlines", in: "Line 1\r\nLine 2\n", exp: "Line 1 Line 2"},
+	{name: "zero width", in: "Think\u200bPad", exp: "ThinkPad"},
+	{name: "invalid utf8", in: "X1\xff Carbon", exp: "X1 Carbon"},
+	{name: "empty", in: " \u0000 ", exp: ""},
+}
+
+func TestSanitizeString(t *testing.T) {
+	for _, c := range casesSanitize {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.exp, sanitizeString(c.in))
+		})
+	}
+}
+
+func TestStringSanitization(t *testing.T) {
+	fnc := func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, `{
+			"ProductId": 1972, "Name": " ThinkPad X1 ",
+			"ModelCode": "21CB000AUS",
+			"Models": [{"ModelCode": "21CB000AUS", "Summary": "i7,\u0000 16GB "}],
+			"Detail": [{"Name": "Memory ", "Value": "16GB  LPDDR5\t"}]
+		}`), nil
+	}
+	ctx := context.Background()
+
+	m, err := newTransportClient(fnc).ModelByID(ctx, 1972, "21CB000AUS")
+	require.NoError(t, err)
+	require.Equal(t, " ThinkPad X1 ", m.Name)
+
+	c := newTransportClient(fnc, WithStringSanitization(true))
+	m, err = c.ModelByID(ctx, 1972, "21CB000AUS")
+	require.NoError(t, err)
+	require.Equal(t, "ThinkPad X1", m.Name)
+	require.Equal(t, "i7, 16GB", m.Models[0].Summary)
+	require.Equal(t, []KeyValue{{Name: "Memory", Value: "16GB LPDDR5"}}, m.Detail)
+
+	p, err := c.ProductByID(ctx, 1972)
+	require.NoError(t, err)
+	require.Equal(t, "ThinkPad X1", p.Name)
+}
@@ -0,0 +1,302 @@
+package psref
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const snapshotVersion = 1
+
+// snapshotHeader is written before the list of products in the snapshot.
+type snapshotHeader struct {
+	Version   int           `json:"version"`
+	Created   time.Time     `json:"created"`
+	Updates   *Updates      `json:"updates"`
+	Products  []ProductType `json:"products"`
+	Withdrawn []ProductType `json:"withdrawn"`
+	Books     []Book        `json:"books"`
+}
+
+// snapshot is a complete decoded snapshot.
+type snapshot struct {
+	snapshotHeader
+	Details []*Product `json:"details"`
+}
+
+// Snapshot fetches the whole catalog and writes it to w as a gzip-compressed JSON document. See LoadSnapshot.
+//
+// It lists all active and withdrawn products and fetches each of them concurrently (see WithConcurrency).
+// All pages of the product model lists are fetched and merged, see ProductByIDFull.
+// Products are written as soon as they are fetched, thus the whole catalog is never kept in memory.
+// Products that are listed, but cannot be found, are skipped.
+//
+// This operation sends thousands of requests. All of them respect the client rate limit,
+// and throttled requests are retried according to the client settings. See WithRate and WithRetry.
+func (c *Client) Snapshot(ctx context.Context, w io.Writer) error {
+	h := snapshotHeader{Version: snapshotVersion, Created: c.now().UTC()}
+	var err error
+	if h.Updates, err = c.Updates(ctx); err != nil {
+		return err
+	}
+	if h.Products, err = c.Products(ctx); err != nil {
+		return err
+	}
+	if h.Withdrawn, err = c.WithdrawnProducts(ctx); err != nil {
+		return err
+	}
+	if h.Books, err = c.Books(ctx); err != nil {
+		return err
+	}
+	ids := productIDs(h.Products, h.Withdrawn)
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	hdr, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	// write the header object without the closing brace, and continue with the list of products
+	bw.Write(hdr[:len(hdr)-1])
+	bw.WriteString(`,"details":[`)
+
+	var (
+		mu    sync.Mutex
+		first = true
+	)
+	enc := json.NewEncoder(bw)
+	err = c.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		p, err := c.ProductByIDFull(ctx, ids[i])
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !first {
+			bw.WriteString(",")
+		}
+		first = false
+		return enc.Encode(p)
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteString("]}")
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// productIDs returns a sorted list of unique product IDs from product type trees.
+func productIDs(lists ...[]ProductType) []PID {
+	seen := make(map[PID]struct{})
+	var ids []PID
+	for _, types := range lists {
+		for _, t := range types {
+			for _, l := range t.Lineup {
+				for _, s := range l.Series {
+					for _, p := range s.Products {
+						if _, ok := seen[p.ID]; ok {
+							continue
+						}
+						seen[p.ID] = struct{}{}
+						ids = append(ids, p.ID)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// LoadSnapshot loads the catalog snapshot written by Client.Snapshot.
+func LoadSnapshot(r io.Reader) (*OfflineClient, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var s snapshot
+	if err := json.NewDecoder(zr).Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", s.Version)
+	}
+	c := &OfflineClient{
+		s:        s.snapshotHeader,
+		products: make(map[PID]*Product, len(s.Details)),
+		models:   make(map[ModelCode][]PID),
+	}
+	for _, p := range s.Details {
+		c.products[p.ID] = p
+		c.ids = append(c.ids, p.ID)
+		for _, m := range p.Models {
+			c.models[m.Code] = append(c.models[m.Code], p.ID)
+		}
+	}
+	sort.Slice(c.ids, func(i, j int) bool { return c.ids[i] < c.ids[j] })
+	return c, nil
+}
+
+// OfflineClient serves PSREF data from a snapshot. See LoadSnapshot.
+//
+// Returned values are shared between calls and must not be modified.
+type OfflineClient struct {
+	s        snapshotHeader
+	ids      []PID
+	products map[PID]*Product
+	models   map[ModelCode][]PID
+}
+
+// Created returns the time when the snapshot was taken.
+func (c *OfflineClient) Created() time.Time {
+	return c.s.Created
+}
+
+// Products lists all active products in the snapshot.
+func (c *OfflineClient) Products(ctx context.Context) ([]ProductType, error) {
+	return c.s.Products, nil
+}
+
+// WithdrawnProducts lists all discontinued products in the snapshot.
+func (c *OfflineClient) WithdrawnProducts(ctx context.Context) ([]ProductType, error) {
+	return c.s.Withdrawn, nil
+}
+
+// Updates returns the PSREF update information at the time of the snapshot.
+func (c *OfflineClient) Updates(ctx context.Context) (*Updates, error) {
+	if c.s.Updates == nil {
+		return nil, ErrNotFound
+	}
+	return c.s.Updates, nil
+}
+
+// Books returns a list of resources for users to read.
+func (c *OfflineClient) Books(ctx context.Context) ([]Book, error) {
+	return c.s.Books, nil
+}
+
+// ProductByID returns the product with a given ID from the snapshot.
+func (c *OfflineClient) ProductByID(ctx context.Context, id PID) (*Product, error) {
+	p, ok := c.products[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return p, nil
+}
+
+// ProductModelsPage is similar to ProductByID. Snapshot keeps all models of the product on the first page,
+// since model list pages are merged when the snapshot is created.
+func (c *OfflineClient) ProductModelsPage(ctx context.Context, id PID, page int) (*Product, error) {
+	p, err := c.ProductByID(ctx, id)
+	if err != nil || page <= 1 {
+		return p, err
+	}
+	cp := *p
+	cp.Models = nil
+	return &cp, nil
+}
+
+// AllProductModels iterates over all models of the product.
+func (c *OfflineClient) AllProductModels(ctx context.Context, id PID) iter.Seq2[ModelInfo, error] {
+	return func(yield func(ModelInfo, error) bool) {
+		p, err := c.ProductByID(ctx, id)
+		if err != nil {
+			yield(ModelInfo{}, err)
+			return
+		}
+		for _, m := range p.Models {
+			if !yield(m, nil) {
+				return
+			}
+		}
+	}
+}
+
+// BatchProductsByID returns multiple products from the snapshot. Products that were not found are omitted.
+func (c *OfflineClient) BatchProductsByID(ctx context.Context, ids []PID) (map[PID]*Product, error) {
+	out := make(map[PID]*Product, len(ids))
+	for _, id := range ids {
+		if p, ok := c.products[id]; ok {
+			out[id] = p
+		}
+	}
+	return out, nil
+}
+
+// ModelByID is not supported, since snapshots do not include model details.
+func (c *OfflineClient) ModelByID(ctx context.Context, id PID, code ModelCode) (*Model, error) {
+	return nil, errNoModels
+}
+
+// ModelByCode is not supported, since snapshots do not include model details.
+func (c *OfflineClient) ModelByCode(ctx context.Context, code ModelCode) (*Model, error) {
+	return nil, errNoModels
+}
+
+var errNoModels = fmt.Errorf("model details are not included in the snapshot: %w", errors.ErrUnsupported)
+
+// ProductByModelCode returns the product which includes a given model.
+func (c *OfflineClient) ProductByModelCode(ctx context.Context, code ModelCode) (*Product, error) {
+	ids := c.models[code]
+	switch len(ids) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return c.products[ids[0]], nil
+	}
+	return nil, &AmbiguousMatchError{Code: code, Products: append([]PID(nil), ids...), Err: ErrMultipleProducts}
+}
+
+// Search finds products by a case-insensitive substring of the product name, key or model code.
+func (c *OfflineClient) Search(ctx context.Context, qu string) ([]SearchResult, error) {
+	return c.search(qu), nil
+}
+
+// SearchPage is similar to Search. All results are returned on the first page.
+func (c *OfflineClient) SearchPage(ctx context.Context, qu string, page int) ([]SearchResult, int, error) {
+	res := c.search(qu)
+	if page > 1 {
+		return nil, len(res), nil
+	}
+	return res, len(res), nil
+}
+
+func (c *OfflineClient) search(qu string) []SearchResult {
+	qu = strings.ToLower(strings.TrimSpace(qu))
+	if qu == "" {
+		return nil
+	}
+	var out []SearchResult
+	for _, id := range c.ids {
+		p := c.products[id]
+		if strings.Contains(strings.ToLower(p.Name), qu) || strings.Contains(strings.ToLower(p.Key), qu) {
+			out = append(out, SearchResult{ID: p.ID, Name: p.Name, Models: len(p.Models)})
+			continue
+		}
+		n := 0
+		for _, m := range p.Models {
+			if strings.Contains(strings.ToLower(string(m.Code)), qu) {
+				n++
+			}
+		}
+		if n != 0 {
+			out = append(out, SearchResult{ID: p.ID, Name: p.Name, Models: n})
+		}
+	}
+	return out
+}
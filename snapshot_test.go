@@ -0,0 +1,103 @@
+package psref_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennwc/psref"
+	"github.com/dennwc/psref/psreftest"
+)
+
+func TestSnapshot(t *testing.T) {
+	srv, c := psreftest.NewServer(psref.WithConcurrency(2))
+	defer srv.Close()
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := c.Snapshot(ctx, &buf)
+	require.NoError(t, err)
+
+	oc, err := psref.LoadSnapshot(&buf)
+	require.NoError(t, err)
+	require.False(t, oc.Created().IsZero())
+
+	var api psref.API = oc
+
+	types, err := api.Products(ctx)
+	require.NoError(t, err)
+	exp, err := c.Products(ctx)
+	require.NoError(t, err)
+	require.Equal(t, exp, types)
+
+	upd, err := api.Updates(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(593), upd.Version)
+
+	p, err := api.ProductByID(ctx, psreftest.ProductID)
+	require.NoError(t, err)
+	exp2, err := c.ProductByID(ctx, psreftest.ProductID)
+	require.NoError(t, err)
+	require.Equal(t, exp2, p)
+
+	// withdrawn product is listed, but has no details
+	_, err = api.ProductByID(ctx, 1001)
+	require.Equal(t, psref.ErrNotFound, err)
+
+	p, err = api.ProductByModelCode(ctx, psreftest.ModelCode)
+	require.NoError(t, err)
+	require.Equal(t, psreftest.ProductID, p.ID)
+
+	res, err := api.Search(ctx, "legion")
+	require.NoError(t, err)
+	require.Equal(t, []psref.SearchResult{{ID: 1234, Name: "Lenovo Legion 5P 15IMH05H", Models: 1}}, res)
+
+	_, err = api.ModelByCode(ctx, psreftest.ModelCode)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+
+	var n int
+	for _, err := range api.AllProductModels(ctx, psreftest.ProductID) {
+		require.NoError(t, err)
+		n++
+	}
+	require.Equal(t, 3, n)
+
+	res, err = api.Search(ctx, "21cb000a")
+	require.NoError(t, err)
+	require.Equal(t, []psref.SearchResult{{ID: psreftest.ProductID, Name: "ThinkPad X1 Carbon Gen 10", Models: 1}}, res)
+}
+
+func TestSnapshotModelPages(t *testing.T) {
+	const extra = psref.ModelCode("21CB00ZZUS")
+	h := psreftest.Handler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/psref/mobile/product/1972" && r.URL.Query().Get("pagenumber") == "2" {
+			_ = json.NewEncoder(w).Encode(psref.Product{ID: psreftest.ProductID, Models: []psref.ModelInfo{{Code: extra}}})
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	c := psref.NewClient(psref.WithBaseURL(srv.URL), psref.WithRetry(1), psref.WithRate(nil))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := c.Snapshot(ctx, &buf)
+	require.NoError(t, err)
+	oc, err := psref.LoadSnapshot(&buf)
+	require.NoError(t, err)
+
+	var codes []psref.ModelCode
+	for m, err := range oc.AllProductModels(ctx, psreftest.ProductID) {
+		require.NoError(t, err)
+		codes = append(codes, m.Code)
+	}
+	require.Contains(t, codes, psreftest.ModelCode)
+	require.Contains(t, codes, extra)
+}
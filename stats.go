@@ -0,0 +1,68 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// Stats is a snapshot of the client request counters. See Client.Stats.
+//
+// All counters are monotonic for the lifetime of the client.
+type Stats struct {
+	Requests      int64 // HTTP requests sent to the API, including retries and hedged requests
+	Retries       int64 // retried request attempts
+	NotFound      int64 // requests that failed with ErrNotFound
+	Errors        int64 // requests that failed with any other error, except for cancelled ones
+	BytesReceived int64 // size of API response bodies
+}
+
+// clientStats holds request counters of the client.
+type clientStats struct {
+	requests atomic.Int64
+	retries  atomic.Int64
+	notFound atomic.Int64
+	errors   atomic.Int64
+	bytes    atomic.Int64
+}
+
+// record updates the counters for a completed request attempt.
+func (s *clientStats) record(err error) {
+	s.requests.Add(1)
+	switch unwrapNoRetry(err) {
+	case nil, ErrNotModified:
+	case ErrNotFound:
+		s.notFound.Add(1)
+	default:
+		// cancelled requests include hedged requests that lost the race, see WithHedging
+		if !errors.Is(err, context.Canceled) {
+			s.errors.Add(1)
+		}
+	}
+}
+
+// Stats returns a snapshot of request counters of the client.
+//
+// Responses served from the cache (see WithCache) are not counted. Downloads are not counted either.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:      c.stats.requests.Load(),
+		Retries:       c.stats.retries.Load(),
+		NotFound:      c.stats.notFound.Load(),
+		Errors:        c.stats.errors.Load(),
+		BytesReceived: c.stats.bytes.Load(),
+	}
+}
+
+// countingReader counts bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,78 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestStats(t *testing.T) {
+	const body = `[{"BookTitle": "A"}]`
+	var fails int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/book":
+			if fails < 2 {
+				fails++
+				return newResponse(req, http.StatusServiceUnavailable, ""), nil
+			}
+			return newResponse(req, http.StatusOK, body), nil
+		case "/psref/mobile/product/1":
+			return newResponse(req, http.StatusBadRequest, ""), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond))
+	require.Equal(t, Stats{}, c.Stats())
+
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	_, err = c.ProductByID(context.Background(), 1)
+	require.Error(t, err)
+	_, err = c.ProductByID(context.Background(), 2)
+	require.Equal(t, ErrNotFound, err)
+
+	require.Equal(t, Stats{
+		Requests:      5,
+		Retries:       2,
+		NotFound:      1,
+		Errors:        3,
+		BytesReceived: int64(len(body)),
+	}, c.Stats())
+}
+
+func TestStatsNotSent(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		return newResponse(req, http.StatusOK, "[]"), nil
+	}, WithRate(rate.NewLimiter(rate.Every(time.Hour), 1)))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+
+	// the second request fails while waiting for the rate limiter
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Books(ctx)
+	require.Error(t, err)
+	require.Equal(t, Stats{Requests: 1, BytesReceived: 2}, c.Stats())
+}
+
+func TestStatsHedging(t *testing.T) {
+	var calls atomic.Int32
+	c := newMockClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}), WithHedging(20*time.Millisecond))
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return c.Stats().Requests == 2
+	}, time.Second, 5*time.Millisecond)
+	require.Zero(t, c.Stats().Errors)
+}
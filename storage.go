@@ -0,0 +1,101 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StorageSpec is a parsed storage drive specification. See Model.Storage.
+type StorageSpec struct {
+	Raw        string // original specification of this drive
+	Count      int    // number of identical drives
+	CapacityGB int    // capacity of a single drive; 1TB is counted as 1000GB
+	MediaType  string // "SSD", "HDD", "eMMC" or "UFS"
+	FormFactor string // e.g. "M.2 2280", "2.5\""
+	Interface  string // "PCIe" or "SATA"
+	PCIeGen    int
+	PCIeLanes  int
+	NVMe       bool
+	Opal       bool // TCG Opal encryption support
+	SED        bool // self-encrypting drive
+}
+
+var (
+	reStorageSize  = regexp.MustCompile(`(?i)(?:(\d+)\s*x\s*)?(` + reNum + `)\s*(GB|TB)\b`)
+	reStorageMedia = regexp.MustCompile(`(?i)\b(SSD|HDD|eMMC|UFS)\b`)
+	reStorageM2    = regexp.MustCompile(`(?i)\bM\.2(?:\s+(22\d\d))?`)
+	reStorage25    = regexp.MustCompile(`2\.5"`)
+	reStoragePCIe  = regexp.MustCompile(`(?i)\bPCIe(?:\s*(\d)\.0)?(?:\s*x\s*(\d+))?`)
+	reStorageSATA  = regexp.MustCompile(`(?i)\bSATA`)
+	reStorageSED   = regexp.MustCompile(`(?i)\bSED\b|self-encrypting`)
+)
+
+var storageMedia = map[string]string{"ssd": "SSD", "hdd": "HDD", "emmc": "eMMC", "ufs": "UFS"}
+
+// Storage parses the storage specification of the model.
+//
+// Configurations with multiple drives separated by "+" return multiple entries.
+// Each entry keeps the original text, since not all details might be decoded.
+func (m *Model) Storage() ([]StorageSpec, error) {
+	s, err := m.detail("Storage")
+	if err != nil {
+		return nil, err
+	}
+	return parseStorage(s)
+}
+
+func parseStorage(s string) ([]StorageSpec, error) {
+	var out []StorageSpec
+	known := false
+	for _, part := range strings.Split(s, "+") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d := parseDrive(part)
+		if d.CapacityGB != 0 || d.MediaType != "" {
+			known = true
+		}
+		out = append(out, d)
+	}
+	if !known {
+		return nil, fmt.Errorf("unrecognized storage format: %q", s)
+	}
+	return out, nil
+}
+
+func parseDrive(s string) StorageSpec {
+	d := StorageSpec{Raw: s, Count: 1}
+	if sub := reStorageSize.FindStringSubmatch(s); sub != nil {
+		if sub[1] != "" {
+			d.Count, _ = strconv.Atoi(sub[1])
+		}
+		size, _ := parseNumber(sub[2])
+		if strings.EqualFold(sub[3], "TB") {
+			size *= 1000
+		}
+		d.CapacityGB = int(size)
+	}
+	if sub := reStorageMedia.FindStringSubmatch(s); sub != nil {
+		d.MediaType = storageMedia[strings.ToLower(sub[1])]
+	}
+	if sub := reStorageM2.FindStringSubmatch(s); sub != nil {
+		d.FormFactor = strings.TrimSpace("M.2 " + sub[1])
+	} else if reStorage25.MatchString(s) {
+		d.FormFactor = `2.5"`
+	}
+	if sub := reStoragePCIe.FindStringSubmatch(s); sub != nil {
+		d.Interface = "PCIe"
+		d.PCIeGen, _ = strconv.Atoi(sub[1])
+		d.PCIeLanes, _ = strconv.Atoi(sub[2])
+	} else if reStorageSATA.MatchString(s) {
+		d.Interface = "SATA"
+	}
+	lower := strings.ToLower(s)
+	d.NVMe = strings.Contains(lower, "nvme")
+	d.Opal = strings.Contains(lower, "opal")
+	d.SED = reStorageSED.MatchString(s)
+	return d
+}
@@ -0,0 +1,73 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStorage = []struct {
+	name string
+	in   string
+	exp  []StorageSpec
+}{
+	{
+		name: "nvme",
+		in:   "256GB SSD M.2 2280 PCIe 4.0x4 NVMe Opal2",
+		exp: []StorageSpec{{
+			Raw: "256GB SSD M.2 2280 PCIe 4.0x4 NVMe Opal2", Count: 1, CapacityGB: 256,
+			MediaType: "SSD", FormFactor: "M.2 2280", Interface: "PCIe", PCIeGen: 4, PCIeLanes: 4,
+			NVMe: true, Opal: true,
+		}},
+	},
+	{
+		name: "hdd",
+		in:   "1TB HDD 5400rpm",
+		exp: []StorageSpec{{
+			Raw: "1TB HDD 5400rpm", Count: 1, CapacityGB: 1000, MediaType: "HDD",
+		}},
+	},
+	{
+		name: "sed",
+		in:   "512GB SSD M.2 2280 SATA SED, used as boot drive",
+		exp: []StorageSpec{{
+			Raw: "512GB SSD M.2 2280 SATA SED, used as boot drive", Count: 1, CapacityGB: 512,
+			MediaType: "SSD", FormFactor: "M.2 2280", Interface: "SATA", SED: true,
+		}},
+	},
+	{
+		name: "multi",
+		in:   "512GB SSD M.2 2242 PCIe NVMe + 2TB HDD 5400rpm 2.5\" SATA",
+		exp: []StorageSpec{
+			{
+				Raw: "512GB SSD M.2 2242 PCIe NVMe", Count: 1, CapacityGB: 512,
+				MediaType: "SSD", FormFactor: "M.2 2242", Interface: "PCIe", NVMe: true,
+			},
+			{
+				Raw: "2TB HDD 5400rpm 2.5\" SATA", Count: 1, CapacityGB: 2000,
+				MediaType: "HDD", FormFactor: "2.5\"", Interface: "SATA",
+			},
+		},
+	},
+	{
+		name: "raid",
+		in:   "2x 1TB SSD M.2 2280 PCIe 4.0x4 NVMe, RAID 0",
+		exp: []StorageSpec{{
+			Raw: "2x 1TB SSD M.2 2280 PCIe 4.0x4 NVMe, RAID 0", Count: 2, CapacityGB: 1000,
+			MediaType: "SSD", FormFactor: "M.2 2280", Interface: "PCIe", PCIeGen: 4, PCIeLanes: 4, NVMe: true,
+		}},
+	},
+}
+
+func TestParseStorage(t *testing.T) {
+	for _, c := range casesStorage {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseStorage(c.in)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, got)
+		})
+	}
+	_, err := parseStorage("None")
+	require.Error(t, err)
+}
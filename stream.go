@@ -0,0 +1,46 @@
+package psref
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamProducts is similar to Products, but decodes product types one by one and calls fn for each of them.
+// It allows processing the catalog without keeping all of it in memory.
+//
+// If the request is retried, product types that were already passed to fn are skipped.
+// An error returned by fn stops the iteration and is returned as-is. Responses are never cached.
+func (c *Client) StreamProducts(ctx context.Context, fn func(ProductType) error) error {
+	done := 0
+	return c.retry(ctx, func(ctx context.Context) error {
+		return c.decodeOnce(ctx, "/", nil, func(r io.Reader) error {
+			dec := json.NewDecoder(r)
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			} else if tok == nil {
+				return nil // null
+			} else if tok != json.Delim('[') {
+				return fmt.Errorf("unexpected JSON token: %v", tok)
+			}
+			for i := 0; dec.More(); i++ {
+				var p ProductType
+				if err := dec.Decode(&p); err != nil {
+					return err
+				}
+				if i < done {
+					continue
+				}
+				c.postProcess(&p)
+				if err := fn(p); err != nil {
+					return noRetry{err}
+				}
+				done++
+			}
+			_, err = dec.Token()
+			return err
+		})
+	})
+}
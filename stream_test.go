@@ -0,0 +1,47 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamProducts(t *testing.T) {
+	const body = `[
+		{"ClassificationName": "Laptops", "ProductLine": [{"ProductLineName": "ThinkPad"}]},
+		{"ClassificationName": "Desktops"},
+		{"ClassificationName": "Tablets"}
+	]`
+	var tries int
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		tries++
+		if tries == 1 {
+			// truncated response
+			return newResponse(req, http.StatusOK, body[:len(body)/2+10]), nil
+		}
+		return newResponse(req, http.StatusOK, body), nil
+	}, WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond))
+
+	var names []string
+	err := c.StreamProducts(context.Background(), func(p ProductType) error {
+		names = append(names, p.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, tries)
+	require.Equal(t, []string{"Laptops", "Desktops", "Tablets"}, names)
+
+	stop := errors.New("stop")
+	tries, names = 1, nil
+	err = c.StreamProducts(context.Background(), func(p ProductType) error {
+		names = append(names, p.Name)
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Equal(t, 2, tries)
+	require.Equal(t, []string{"Laptops"}, names)
+}
@@ -0,0 +1,85 @@
+package psref
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Keys of the map returned by ModelInfo.ParseSummary.
+const (
+	SummaryCPU      = "CPU"
+	SummaryMemory   = "Memory"
+	SummaryStorage  = "Storage"
+	SummaryDisplay  = "Display"
+	SummaryGraphics = "Graphics"
+	SummaryOS       = "OS"
+	SummaryOther    = "Other"
+)
+
+// summaryOrder is the usual order of fragments in model summaries. It is used for fragments that cannot be classified,
+// except for SummaryDisplay, which is only assigned to fragments matching the display pattern.
+var summaryOrder = []string{SummaryCPU, SummaryMemory, SummaryStorage, SummaryDisplay}
+
+// summaryKinds lists patterns used to classify summary fragments. The first match wins.
+var summaryKinds = []struct {
+	key string
+	re  *regexp.Regexp
+}{
+	{SummaryStorage, regexp.MustCompile(`(?i)\b(?:SSD|HDD|eMMC|UFS)\b|\d\s*TB\b`)},
+	// integrated graphics like "UHD Graphics" must be matched before the display
+	{SummaryGraphics, regexp.MustCompile(`(?i)\b(?:GTX|RTX|Radeon|Arc\s+A\d+|Quadro|Iris(?:\s+Xe)?|(?:UHD|Intel|Arc)\s+Graphics)\b`)},
+	{SummaryDisplay, regexp.MustCompile(`(?i)\d(?:\.\d)?"|\b(?:FHD|WUXGA|WQXGA|QHD|UHD|OLED|IPS|\d+Hz)\b|\d(?:\.\d)?K\b`)},
+	{SummaryOS, regexp.MustCompile(`(?i)\b(?:Win(?:dows)?|No OS|Linux|Ubuntu|Chrome\s*OS|DOS)\b`)},
+	{SummaryCPU, regexp.MustCompile(`(?i)\b(?:i[3579]-\w+|Core|Ryzen|Celeron|Pentium|Athlon|Xeon|Snapdragon|Ultra\s+\d)\b`)},
+	{SummaryMemory, regexp.MustCompile(`(?i)^\d+\s*GB\b`)},
+}
+
+// ParseSummary splits the model summary (e.g. "i5-1240P, 16GB, 256GB SSD, 14" WUXGA IPS, Win 11 Pro")
+// into specification fragments, indexed by SummaryCPU, SummaryMemory, etc.
+//
+// Summaries have no fixed format, thus fragments are classified by their content.
+// Fragments that cannot be classified are assigned to the next unused key in the usual summary order:
+// CPU, memory, storage. Display is never assigned this way, since its fragments have a recognizable size,
+// resolution or panel type. Remaining fragments are joined under SummaryOther.
+// Missing fragments are not included in the map.
+func (mi ModelInfo) ParseSummary() map[string]string {
+	out := make(map[string]string)
+	next := 0
+	for _, part := range strings.Split(mi.Summary, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key := ""
+		for _, k := range summaryKinds {
+			if _, ok := out[k.key]; !ok && k.re.MatchString(part) {
+				key = k.key
+				break
+			}
+		}
+		if key == "" {
+			for ; next < len(summaryOrder); next++ {
+				if summaryOrder[next] == SummaryDisplay {
+					continue
+				}
+				if _, ok := out[summaryOrder[next]]; !ok {
+					key = summaryOrder[next]
+					break
+				}
+			}
+		}
+		if key == "" {
+			if v := out[SummaryOther]; v != "" {
+				part = v + ", " + part
+			}
+			key = SummaryOther
+		}
+		out[key] = part
+		for i, k := range summaryOrder {
+			if k == key && i >= next {
+				next = i + 1
+			}
+		}
+	}
+	return out
+}
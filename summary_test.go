@@ -0,0 +1,82 @@
+package psref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSummary = []struct {
+	name string
+	in   string
+	exp  map[string]string
+}{
+	{
+		name: "laptop",
+		in:   `i5-1240P, 16GB, 256GB SSD, 14" WUXGA IPS, Win 11 Pro`,
+		exp: map[string]string{
+			SummaryCPU: "i5-1240P", SummaryMemory: "16GB", SummaryStorage: "256GB SSD",
+			SummaryDisplay: `14" WUXGA IPS`, SummaryOS: "Win 11 Pro",
+		},
+	},
+	{
+		name: "graphics",
+		in:   `i7-10750H, 16GB, 512GB SSD, 15.6" FHD IPS, GTX 1660 Ti 6GB, No OS`,
+		exp: map[string]string{
+			SummaryCPU: "i7-10750H", SummaryMemory: "16GB", SummaryStorage: "512GB SSD",
+			SummaryDisplay: `15.6" FHD IPS`, SummaryGraphics: "GTX 1660 Ti 6GB", SummaryOS: "No OS",
+		},
+	},
+	{
+		name: "missing",
+		in:   `Ryzen 5 5600U, 1TB SSD`,
+		exp:  map[string]string{SummaryCPU: "Ryzen 5 5600U", SummaryStorage: "1TB SSD"},
+	},
+	{
+		name: "unknown",
+		in:   `Intel N100, 8GB, 128GB eMMC, Foo, Bar, Baz`,
+		exp: map[string]string{
+			SummaryCPU: "Intel N100", SummaryMemory: "8GB", SummaryStorage: "128GB eMMC",
+			SummaryOther: "Foo, Bar, Baz",
+		},
+	},
+	{
+		name: "integrated graphics",
+		in:   `i5-1240P, 16GB, 512GB SSD, Intel Iris Xe, 14" WUXGA IPS`,
+		exp: map[string]string{
+			SummaryCPU: "i5-1240P", SummaryMemory: "16GB", SummaryStorage: "512GB SSD",
+			SummaryGraphics: "Intel Iris Xe", SummaryDisplay: `14" WUXGA IPS`,
+		},
+	},
+	{
+		name: "integrated graphics without display",
+		in:   `i3-1215U, 8GB, 256GB SSD, Intel UHD Graphics`,
+		exp: map[string]string{
+			SummaryCPU: "i3-1215U", SummaryMemory: "8GB", SummaryStorage: "256GB SSD",
+			SummaryGraphics: "Intel UHD Graphics",
+		},
+	},
+	{
+		name: "radeon graphics",
+		in:   `Ryzen 5 7530U, 8GB, 512GB SSD, AMD Radeon Graphics`,
+		exp: map[string]string{
+			SummaryCPU: "Ryzen 5 7530U", SummaryMemory: "8GB", SummaryStorage: "512GB SSD",
+			SummaryGraphics: "AMD Radeon Graphics",
+		},
+	},
+	{
+		name: "empty",
+		in:   ``,
+		exp:  map[string]string{},
+	},
+}
+
+func TestParseSummary(t *testing.T) {
+	for _, c := range casesSummary {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got := ModelInfo{Summary: c.in}.ParseSummary()
+			require.Equal(t, c.exp, got)
+		})
+	}
+}
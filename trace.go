@@ -0,0 +1,56 @@
+package psref
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Span attribute keys set on tracers implementing SpanAttributer.
+const (
+	SpanAttrPath   = "url.path"
+	SpanAttrStatus = "http.response.status_code"
+)
+
+// Tracer starts a span for each API request attempt. See WithTracer.
+//
+// It allows integrating with tracing libraries like OpenTelemetry without depending on them directly.
+// Span names only include the API endpoint with IDs replaced by placeholders, e.g. "psref GET /psref/mobile/product/{id}".
+// Tracers that also implement SpanAttributer receive the full path and the response status as attributes.
+type Tracer interface {
+	// StartSpan starts a new span with a given name and returns the context with the span,
+	// as well as a function to end the span.
+	//
+	// The end function receives the request error, or nil if the request succeeded.
+	// A response status can be extracted from the error: ErrNotFound means 404,
+	// while StatusError holds the status for other failed requests.
+	StartSpan(ctx context.Context, name string) (context.Context, func(err error))
+}
+
+// SpanAttributer is an optional interface for a Tracer that supports span attributes.
+type SpanAttributer interface {
+	// SetSpanAttributes sets attributes on the span stored in the context returned by StartSpan.
+	//
+	// The client sets SpanAttrPath when the span starts, and SpanAttrStatus before it ends, if the status is known.
+	SetSpanAttributes(ctx context.Context, attrs ...slog.Attr)
+}
+
+// spanName returns the span name for a given API path.
+func spanName(path string) string {
+	return "psref GET " + metricsEndpoint(path)
+}
+
+// startSpan starts a span for a request attempt to a given API path. See WithTracer.
+func (c *Client) startSpan(ctx context.Context, path string) (context.Context, func(err error)) {
+	ctx, end := c.tracer.StartSpan(ctx, spanName(path))
+	sa, ok := c.tracer.(SpanAttributer)
+	if !ok {
+		return ctx, end
+	}
+	sa.SetSpanAttributes(ctx, slog.String(SpanAttrPath, path))
+	return ctx, func(err error) {
+		if status := metricsStatus(err); status != 0 {
+			sa.SetSpanAttributes(ctx, slog.Int(SpanAttrStatus, status))
+		}
+		end(err)
+	}
+}
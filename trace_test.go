@@ -0,0 +1,94 @@
+package psref
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+type span struct {
+	name   string
+	err    error
+	ended  bool
+	hasCtx bool
+	attrs  map[string]any
+}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*span
+}
+
+func (tr *testTracer) StartSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	s := &span{name: name}
+	tr.mu.Lock()
+	tr.spans = append(tr.spans, s)
+	tr.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, s), func(err error) {
+		s.ended = true
+		s.err = err
+	}
+}
+
+func (tr *testTracer) SetSpanAttributes(ctx context.Context, attrs ...slog.Attr) {
+	s := ctx.Value(spanKey{}).(*span)
+	if s.attrs == nil {
+		s.attrs = make(map[string]any)
+	}
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value.Any()
+	}
+}
+
+func TestTracer(t *testing.T) {
+	tr := &testTracer{}
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		if s, ok := req.Context().Value(spanKey{}).(*span); ok {
+			s.hasCtx = true
+		}
+		switch req.URL.Path {
+		case "/psref/mobile/book":
+			return newResponse(req, http.StatusOK, "[]"), nil
+		case "/psref/mobile/product/1":
+			return newResponse(req, http.StatusForbidden, ""), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	}, WithTracer(tr), WithRetry(0))
+
+	_, err := c.Books(context.Background())
+	require.NoError(t, err)
+	_, err = c.ProductByID(context.Background(), 1)
+	require.Error(t, err)
+	_, err = c.ProductByID(context.Background(), 2)
+	require.Equal(t, ErrNotFound, err)
+
+	require.Len(t, tr.spans, 3)
+	for _, s := range tr.spans {
+		require.True(t, s.ended, s.name)
+		require.True(t, s.hasCtx, s.name)
+	}
+	require.Equal(t, "psref GET /psref/mobile/book", tr.spans[0].name)
+	require.NoError(t, tr.spans[0].err)
+	require.Equal(t, map[string]any{
+		SpanAttrPath: "/psref/mobile/book", SpanAttrStatus: int64(http.StatusOK),
+	}, tr.spans[0].attrs)
+
+	require.Equal(t, "psref GET /psref/mobile/product/{id}", tr.spans[1].name)
+	var serr *StatusError
+	require.True(t, errors.As(tr.spans[1].err, &serr))
+	require.Equal(t, http.StatusForbidden, serr.StatusCode)
+	require.Equal(t, map[string]any{
+		SpanAttrPath: "/psref/mobile/product/1", SpanAttrStatus: int64(http.StatusForbidden),
+	}, tr.spans[1].attrs)
+
+	require.Equal(t, "psref GET /psref/mobile/product/{id}", tr.spans[2].name)
+	require.Equal(t, ErrNotFound, tr.spans[2].err)
+	require.Equal(t, int64(http.StatusNotFound), tr.spans[2].attrs[SpanAttrStatus])
+}
@@ -2,6 +2,8 @@ package psref
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,6 +16,33 @@ type PID uint64
 // ModelCode is an alphanumeric product code.
 type ModelCode string
 
+const (
+	minModelCodeLen = 7
+	maxModelCodeLen = 12
+)
+
+// Valid checks if the model code has an expected format: upper case letters and digits, usually 10 characters long (e.g. "21CB000AUS").
+func (c ModelCode) Valid() bool {
+	if len(c) < minModelCodeLen || len(c) > maxModelCodeLen {
+		return false
+	}
+	for _, r := range c {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseModelCode trims and upper-cases the model code and checks that it is valid. See ModelCode.Valid.
+func ParseModelCode(s string) (ModelCode, error) {
+	c := ModelCode(strings.ToUpper(strings.TrimSpace(s)))
+	if !c.Valid() {
+		return "", fmt.Errorf("invalid model code: %q", s)
+	}
+	return c, nil
+}
+
 var (
 	_ json.Marshaler   = Date{}
 	_ json.Unmarshaler = (*Date)(nil)
@@ -26,11 +55,20 @@ func normalizeURL(s string) string {
 // Date is wrapper around time.Time which uses custom JSON encoding.
 type Date time.Time
 
+// UnmarshalJSON implements json.Unmarshaler. Null and empty strings decode to a zero Date.
 func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
 	t, err := time.Parse("2006-01-02", s)
 	if err != nil {
 		return err
@@ -39,11 +77,74 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler. A zero Date is encoded as null.
 func (d Date) MarshalJSON() ([]byte, error) {
-	s := time.Time(d).Format("2006-01-02")
-	return json.Marshal(s)
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// Time returns the date as time.Time.
+func (d Date) Time() time.Time {
+	return time.Time(d)
 }
 
+// IsZero reports whether the date is not set.
+func (d Date) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+// String formats the date as YYYY-MM-DD. It returns an empty string for a zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return time.Time(d).Format("2006-01-02")
+}
+
+// WithdrawnStatus is a status code of the product or model ("P_WdStatus" and "M_WdStatus" fields in the API).
+// See Product.Withdrawn and Model.Withdrawn.
+//
+// Only two codes were observed in API responses so far. Other codes are reported as is by String.
+type WithdrawnStatus int
+
+const (
+	// StatusActive is set for all products in the product list and for their models.
+	StatusActive = WithdrawnStatus(0)
+	// StatusWithdrawn is set for products in the withdrawn product list. See Client.WithdrawnProducts.
+	StatusWithdrawn = WithdrawnStatus(1)
+)
+
+// String returns "active" or "withdrawn" for known codes, and "WithdrawnStatus(N)" for unknown ones.
+func (s WithdrawnStatus) String() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusWithdrawn:
+		return "withdrawn"
+	}
+	return "WithdrawnStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Normalizer is implemented by API types that fix up URLs after decoding.
+//
+// The client normalizes all responses, unless WithRawImageURLs is set.
+// Data constructed or decoded by other means (e.g. from a custom cache) can be normalized the same way.
+// Normalizing the data multiple times has no effect.
+type Normalizer interface {
+	Normalize()
+}
+
+var (
+	_ Normalizer = (*ProductType)(nil)
+	_ Normalizer = (*ProductLine)(nil)
+	_ Normalizer = (*Series)(nil)
+	_ Normalizer = (*ProductShort)(nil)
+	_ Normalizer = (*Product)(nil)
+	_ Normalizer = (*Model)(nil)
+)
+
 // ProductType is a top-level product type which includes multiple product lineups.
 type ProductType struct {
 	Name    string        `json:"ClassificationName"`
@@ -56,9 +157,10 @@ type productType struct {
 	Lineup []ProductLine `json:"ProductLine"`
 }
 
-func (p *ProductType) normalize() {
+// Normalize fixes up URLs of all lineups in the product type. See Normalizer.
+func (p *ProductType) Normalize() {
 	for i := range p.Lineup {
-		p.Lineup[i].normalize()
+		p.Lineup[i].Normalize()
 	}
 }
 
@@ -69,10 +171,11 @@ type ProductLine struct {
 	Series []Series `json:"Series"`
 }
 
-func (p *ProductLine) normalize() {
+// Normalize fixes up the image URL of the lineup and URLs of all its series. See Normalizer.
+func (p *ProductLine) Normalize() {
 	p.Image = normalizeURL(p.Image)
 	for i := range p.Series {
-		p.Series[i].normalize()
+		p.Series[i].Normalize()
 	}
 }
 
@@ -81,9 +184,10 @@ type Series struct {
 	Products []ProductShort `json:"Products"`
 }
 
-func (p *Series) normalize() {
+// Normalize fixes up URLs of all products in the series. See Normalizer.
+func (p *Series) Normalize() {
 	for i := range p.Products {
-		p.Products[i].normalize()
+		p.Products[i].Normalize()
 	}
 }
 
@@ -98,7 +202,8 @@ type ProductShort struct {
 	ConfigModified  Date   `json:"ConfigModifyDateTime"`
 }
 
-func (p *ProductShort) normalize() {}
+// Normalize implements Normalizer. Short product descriptions have no URLs, so it does nothing.
+func (p *ProductShort) Normalize() {}
 
 // ModelInfo is a basic model info used in the model list.
 type ModelInfo struct {
@@ -129,9 +234,18 @@ type Product struct {
 	Images          []string        `json:"Images"`
 	Models          []ModelInfo     `json:"Models"`
 	Docs            []Documentation `json:"Documentations"`
+
+	// DecodeErrors lists fields that failed to decode and were left empty. See WithLenientDecode.
+	DecodeErrors []FieldError `json:"-"`
 }
 
-func (p *Product) normalize() {
+func (p *Product) setDecodeErrors(errs []FieldError) {
+	p.DecodeErrors = errs
+}
+
+// Normalize unescapes the image URL and replaces backslashes with slashes in all URLs of the product. See Normalizer.
+func (p *Product) Normalize() {
+	p.Image = unescapeImage(p.Image)
 	p.RefURL = normalizeURL(p.RefURL)
 	p.SpecURL = normalizeURL(p.SpecURL)
 	p.US_Pdf = normalizeURL(p.US_Pdf)
@@ -141,20 +255,150 @@ func (p *Product) normalize() {
 	for i := range p.Images {
 		p.Images[i] = normalizeURL(p.Images[i])
 	}
+	for i := range p.Docs {
+		p.Docs[i].URL = normalizeURL(p.Docs[i].URL)
+	}
+}
+
+// DocsByTitle returns product documentation with titles containing a given substring, ignoring the case.
+// Documents with the same URL are returned only once.
+func (p *Product) DocsByTitle(substr string) []Documentation {
+	substr = strings.ToLower(substr)
+	var out []Documentation
+	for _, d := range dedupDocs(p.Docs) {
+		if strings.Contains(strings.ToLower(d.Title), substr) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// dedupDocs removes documents with duplicate URLs, preserving the order.
+func dedupDocs(docs []Documentation) []Documentation {
+	seen := make(map[string]struct{}, len(docs))
+	out := make([]Documentation, 0, len(docs))
+	for _, d := range docs {
+		if _, ok := seen[d.URL]; ok {
+			continue
+		}
+		seen[d.URL] = struct{}{}
+		out = append(out, d)
+	}
+	return out
+}
+
+// Withdrawn checks if the product is discontinued. Unknown status codes are considered withdrawn as well.
+func (p *Product) Withdrawn() bool {
+	return WithdrawnStatus(p.WithdrawnStatus) != StatusActive
+}
+
+// Validate checks that the product has critical fields set. It helps to distinguish an empty API response from a real product.
+//
+// Only the ID, the key or name, and the model list are checked. All problems are reported as a joined error.
+func (p *Product) Validate() error {
+	var errs []error
+	if p.ID == 0 {
+		errs = append(errs, errors.New("product ID is not set"))
+	}
+	if p.Key == "" && p.Name == "" {
+		errs = append(errs, errors.New("product key and name are not set"))
+	}
+	if len(p.Models) == 0 {
+		errs = append(errs, errors.New("product has no models"))
+	}
+	return errors.Join(errs...)
+}
+
+// FilterModels returns all product models matching the predicate. See HasSummarySubstring.
+func (p *Product) FilterModels(pred func(ModelInfo) bool) []ModelInfo {
+	var out []ModelInfo
+	for _, m := range p.Models {
+		if pred(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// HasSummarySubstring returns a predicate that matches models with a given substring in the summary.
+// The match is case-sensitive. See HasSummarySubstringFold for a case-insensitive version.
+func HasSummarySubstring(s string) func(ModelInfo) bool {
+	return func(m ModelInfo) bool {
+		return strings.Contains(m.Summary, s)
+	}
+}
+
+// HasSummarySubstringFold is similar to HasSummarySubstring, but ignores the case.
+func HasSummarySubstringFold(s string) func(ModelInfo) bool {
+	s = strings.ToLower(s)
+	return func(m ModelInfo) bool {
+		return strings.Contains(strings.ToLower(m.Summary), s)
+	}
+}
+
+// PreferredPDF returns the URL of the product specification PDF for a given geo.
+//
+// Geo "US" or "NA" selects the US version, while "EMEA", "EU" and "WE" select the EMEA version.
+// The worldwide version is returned for any other geo, or if the regional version is not available.
+func (p *Product) PreferredPDF(geo string) string {
+	var u string
+	switch strings.ToUpper(strings.TrimSpace(geo)) {
+	case "US", "NA":
+		u = p.US_Pdf
+	case "EMEA", "EU", "WE":
+		u = p.EMEA_Pdf
+	}
+	if u == "" {
+		u = p.WW_Pdf
+	}
+	return u
 }
 
 // UpdatedProduct is an information about product update used in the PSREF Updates info.
 type UpdatedProduct struct {
-	ID     PID    `json:"productId"`
-	Title  string `json:"title"`
-	Reason string `json:"reason,omitempty"`
+	ID     PID          `json:"productId"`
+	Title  string       `json:"title"`
+	Reason UpdateReason `json:"reason,omitempty"`
+}
+
+// UpdateReason describes why the product was updated. See UpdatedProduct.
+//
+// Reasons not listed in the constants are preserved as-is.
+type UpdateReason string
+
+const (
+	// ReasonNewModel is set when new models were added to the product.
+	ReasonNewModel = UpdateReason("new model added")
+	// ReasonSpecUpdated is set when the product specification changed.
+	ReasonSpecUpdated = UpdateReason("spec updated")
+)
+
+// Known checks if the reason is one of the known constants.
+func (r UpdateReason) Known() bool {
+	switch r {
+	case ReasonNewModel, ReasonSpecUpdated:
+		return true
+	}
+	return false
 }
 
 var (
 	reVersion   = regexp.MustCompile(`Version (\d+)`)
-	reVersionTS = regexp.MustCompile(` (\w{3}\.\d{1,2}, \d{4})`)
+	reVersionTS = regexp.MustCompile(`\b([A-Za-z]{3,9})\.?\s*(\d{1,2}),\s*(\d{4})\b`)
 )
 
+// parseVersionTS parses the date of the data version, e.g. "Oct.5, 2022", "Sept.12, 2023" or "May 5, 2022".
+// Month names may be abbreviated to any length of at least 3 letters.
+func parseVersionTS(s string) (time.Time, bool) {
+	for _, sub := range reVersionTS.FindAllStringSubmatch(s, -1) {
+		month := strings.ToUpper(sub[1][:1]) + strings.ToLower(sub[1][1:3])
+		if ts, err := time.Parse("Jan 2 2006", month+" "+sub[2]+" "+sub[3]); err == nil {
+			return ts, true
+		}
+	}
+	return time.Time{}, false
+}
+
 type Updates struct {
 	Version      uint64    `json:"x_Version"`   // TODO: upstream
 	VersionTS    time.Time `json:"x_VersionTS"` // TODO: upstream
@@ -165,22 +409,33 @@ type Updates struct {
 	Withdrawn []UpdatedProduct `json:"Withdrawn"`
 }
 
-func (upd *Updates) parse() {
+// HasVersion checks if the version of updates is known.
+func (upd *Updates) HasVersion() bool {
+	return upd != nil && upd.Version != 0
+}
+
+// parse extracts the version information from the title and the reasons from update entries.
+//
+// It returns an error if the version title is present, but the version number cannot be parsed.
+// Other fields are still populated in this case.
+func (upd *Updates) parse() error {
 	if upd == nil {
-		return
+		return nil
 	}
 	upd.VersionTitle = strings.TrimPrefix(upd.VersionTitle, "<b>")
 	upd.VersionTitle = strings.TrimSuffix(upd.VersionTitle, "</b>")
 	upd.VersionTitle = strings.TrimSpace(upd.VersionTitle)
+	var err error
 	if sub := reVersion.FindStringSubmatch(upd.VersionTitle); len(sub) > 1 {
-		if vers, err := strconv.ParseUint(sub[1], 10, 64); err == nil {
+		if vers, perr := strconv.ParseUint(sub[1], 10, 64); perr == nil {
 			upd.Version = vers
 		}
 	}
-	if sub := reVersionTS.FindStringSubmatch(upd.VersionTitle); len(sub) > 1 {
-		if ts, err := time.Parse("Jan.2, 2006", sub[1]); err == nil {
-			upd.VersionTS = ts
-		}
+	if upd.VersionTitle != "" && upd.Version == 0 {
+		err = fmt.Errorf("unrecognized version format: %q", upd.VersionTitle)
+	}
+	if ts, ok := parseVersionTS(upd.VersionTitle); ok {
+		upd.VersionTS = ts
 	}
 	for i := range upd.Updated {
 		u := &upd.Updated[i]
@@ -189,15 +444,13 @@ func (upd *Updates) parse() {
 		}
 		title := u.Title[:len(u.Title)-1]
 		if i := strings.LastIndexByte(title, '('); i > 0 {
-			reason := title[i+1:]
-			switch reason {
-			case "new model added",
-				"spec updated":
+			if reason := strings.TrimSpace(title[i+1:]); reason != "" {
 				u.Title = strings.TrimSpace(u.Title[:i])
-				u.Reason = reason
+				u.Reason = UpdateReason(reason)
 			}
 		}
 	}
+	return err
 }
 
 // Book is a reference to a resource for users to read.
@@ -223,6 +476,35 @@ type Model struct {
 	Code            ModelCode  `json:"ModelCode"`
 }
 
+// Normalize is similar to Product.Normalize, but also fixes up the model URL. See Normalizer.
+func (m *Model) Normalize() {
+	m.Product.Normalize()
+	m.RefURL = normalizeURL(m.RefURL)
+}
+
+// Withdrawn checks if the model, or the whole product is discontinued.
+// Unknown status codes are considered withdrawn as well.
+func (m *Model) Withdrawn() bool {
+	return WithdrawnStatus(m.WithdrawnStatus) != StatusActive || m.Product.Withdrawn()
+}
+
+// Validate checks that the model has critical fields set. It helps to distinguish an empty API response from a real model.
+//
+// Only the product ID, the model code and the presence of specifications are checked. All problems are reported as a joined error.
+func (m *Model) Validate() error {
+	var errs []error
+	if m.ID == 0 {
+		errs = append(errs, errors.New("product ID is not set"))
+	}
+	if m.Code == "" {
+		errs = append(errs, errors.New("model code is not set"))
+	}
+	if len(m.Detail) == 0 {
+		errs = append(errs, errors.New("model has no specifications"))
+	}
+	return errors.Join(errs...)
+}
+
 // DetailByName searches a specification value by the key name.
 func (m *Model) DetailByName(name string) string {
 	for _, v := range m.Detail {
@@ -232,3 +514,72 @@ func (m *Model) DetailByName(name string) string {
 	}
 	return ""
 }
+
+// DetailsByName returns all specification values with a given key name, in the order they are listed.
+//
+// Some models list the same name multiple times, for example, one "Storage" row for each drive.
+func (m *Model) DetailsByName(name string) []string {
+	var out []string
+	for _, v := range m.Detail {
+		if v.Name == name {
+			out = append(out, v.Value)
+		}
+	}
+	return out
+}
+
+// DetailMap returns all specification values indexed by the name. For duplicate names, the first value is kept.
+//
+// The map is built on each call, thus callers that extract multiple values should keep it instead of calling DetailByName repeatedly.
+func (m *Model) DetailMap() map[string]string {
+	out := make(map[string]string, len(m.Detail))
+	for _, v := range m.Detail {
+		if _, ok := out[v.Name]; !ok {
+			out[v.Name] = v.Value
+		}
+	}
+	return out
+}
+
+// SpecDiff is a difference in a single specification value between two models. See CompareModels.
+type SpecDiff struct {
+	Name   string
+	ValueA string
+	ValueB string
+}
+
+// CompareModels lists all specification values that differ between two models, including ones present only in one of them.
+//
+// Differences are listed in the order of a.Detail, followed by values present only in b.
+// For duplicate names, only the first value is compared.
+func CompareModels(a, b *Model) []SpecDiff {
+	seen := make(map[string]struct{}, len(a.Detail))
+	inB := b.DetailMap()
+	var out []SpecDiff
+	for _, v := range a.Detail {
+		if _, ok := seen[v.Name]; ok {
+			continue
+		}
+		seen[v.Name] = struct{}{}
+		if vb, ok := inB[v.Name]; !ok || vb != v.Value {
+			out = append(out, SpecDiff{Name: v.Name, ValueA: v.Value, ValueB: vb})
+		}
+	}
+	for _, v := range b.Detail {
+		if _, ok := seen[v.Name]; ok {
+			continue
+		}
+		seen[v.Name] = struct{}{}
+		out = append(out, SpecDiff{Name: v.Name, ValueB: v.Value})
+	}
+	return out
+}
+
+// detail is similar to DetailByName, but returns an error wrapping ErrNotFound if the value is missing or empty.
+func (m *Model) detail(name string) (string, error) {
+	v := strings.TrimSpace(m.DetailByName(name))
+	if v == "" {
+		return "", fmt.Errorf("detail %q: %w", name, ErrNotFound)
+	}
+	return v, nil
+}
@@ -1,7 +1,10 @@
 package psref
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -10,3 +13,283 @@ func TestImageUnescape(t *testing.T) {
 	s := unescapeImage("http%3a%2f%2fpsref.lenovo.com%2fsyspool%2fSys%2fImage%2fLegion%2fLenovo_Legion_5P_15IMH05H%2fCompressedimageForMobileShare%2fLenovo_Legion_5P_15IMH05H_CT1_01.png")
 	require.Equal(t, "http://psref.lenovo.com/syspool/Sys/Image/Legion/Lenovo_Legion_5P_15IMH05H/CompressedimageForMobileShare/Lenovo_Legion_5P_15IMH05H_CT1_01.png", s)
 }
+
+func TestNormalize(t *testing.T) {
+	m := &Model{
+		Product: Product{
+			Image:   "http%3a%2f%2fpsref.lenovo.com%2fsyspool%2fSys%2fImage%2fX1.png",
+			SpecURL: "https:\\\\psref.lenovo.com\\spec.pdf",
+			Docs:    []Documentation{{URL: "https:\\\\psref.lenovo.com\\ug.pdf"}},
+		},
+		RefURL: "https:\\\\psref.lenovo.com\\Detail",
+	}
+	for i := 0; i < 2; i++ {
+		m.Normalize()
+		require.Equal(t, "http://psref.lenovo.com/syspool/Sys/Image/X1.png", m.Image)
+		require.Equal(t, "https://psref.lenovo.com/spec.pdf", m.SpecURL)
+		require.Equal(t, "https://psref.lenovo.com/ug.pdf", m.Docs[0].URL)
+		require.Equal(t, "https://psref.lenovo.com/Detail", m.RefURL)
+	}
+
+	types := []ProductType{{Lineup: []ProductLine{{Image: "https:\\\\psref.lenovo.com\\line.png"}}}}
+	var n Normalizer = &types[0]
+	n.Normalize()
+	require.Equal(t, "https://psref.lenovo.com/line.png", types[0].Lineup[0].Image)
+}
+
+func TestPreferredPDF(t *testing.T) {
+	p := &Product{US_Pdf: "us", EMEA_Pdf: "emea", WW_Pdf: "ww"}
+	require.Equal(t, "us", p.PreferredPDF("us"))
+	require.Equal(t, "emea", p.PreferredPDF("EMEA"))
+	require.Equal(t, "ww", p.PreferredPDF("AP"))
+	require.Equal(t, "ww", p.PreferredPDF(""))
+
+	p.US_Pdf = ""
+	require.Equal(t, "ww", p.PreferredPDF("US"))
+}
+
+func TestFilterModels(t *testing.T) {
+	p := &Product{Models: []ModelInfo{
+		{Code: "A", Summary: "i5-1240P, 16GB, 256GB SSD"},
+		{Code: "B", Summary: "i7-1260P, 16GB, 512GB SSD"},
+		{Code: "C", Summary: "i7-1280P, 32GB, 1TB ssd"},
+	}}
+	codes := func(list []ModelInfo) []ModelCode {
+		var out []ModelCode
+		for _, m := range list {
+			out = append(out, m.Code)
+		}
+		return out
+	}
+	require.Equal(t, []ModelCode{"B", "C"}, codes(p.FilterModels(HasSummarySubstring("i7"))))
+	require.Equal(t, []ModelCode{"A", "B"}, codes(p.FilterModels(HasSummarySubstring("SSD"))))
+	require.Equal(t, []ModelCode{"A", "B", "C"}, codes(p.FilterModels(HasSummarySubstringFold("ssd"))))
+	require.Empty(t, p.FilterModels(HasSummarySubstring("OLED")))
+}
+
+func TestCompareModels(t *testing.T) {
+	a := &Model{Detail: []KeyValue{
+		{Name: "Processor", Value: "i5"},
+		{Name: "Memory", Value: "16GB"},
+		{Name: "Color", Value: "Black"},
+		{Name: "Camera", Value: "720p"},
+	}}
+	b := &Model{Detail: []KeyValue{
+		{Name: "Memory", Value: "16GB"},
+		{Name: "WWAN", Value: "5G"},
+		{Name: "Processor", Value: "i7"},
+		{Name: "Color", Value: "Black"},
+	}}
+	require.Equal(t, []SpecDiff{
+		{Name: "Processor", ValueA: "i5", ValueB: "i7"},
+		{Name: "Camera", ValueA: "720p"},
+		{Name: "WWAN", ValueB: "5G"},
+	}, CompareModels(a, b))
+	require.Empty(t, CompareModels(a, a))
+}
+
+func TestDetailMap(t *testing.T) {
+	m := &Model{Detail: []KeyValue{
+		{Name: "Storage", Value: "256GB SSD"},
+		{Name: "Memory", Value: "16GB"},
+		{Name: "Storage", Value: "1TB HDD"},
+	}}
+	require.Equal(t, map[string]string{
+		"Storage": "256GB SSD",
+		"Memory":  "16GB",
+	}, m.DetailMap())
+	require.Equal(t, []string{"256GB SSD", "1TB HDD"}, m.DetailsByName("Storage"))
+	require.Equal(t, "256GB SSD", m.DetailByName("Storage"))
+	require.Nil(t, m.DetailsByName("Graphics"))
+}
+
+func TestDocsByTitle(t *testing.T) {
+	p := &Product{Docs: []Documentation{
+		{Title: "User Guide", URL: "https:\\\\example.com\\ug.pdf"},
+		{Title: "Hardware Maintenance Manual", URL: "https:\\\\example.com\\hmm.pdf"},
+		{Title: "User Guide (copy)", URL: "https:\\\\example.com\\ug.pdf"},
+	}}
+	p.Normalize()
+	require.Equal(t, []Documentation{
+		{Title: "User Guide", URL: "https://example.com/ug.pdf"},
+	}, p.DocsByTitle("guide"))
+	require.Len(t, p.DocsByTitle(""), 2)
+}
+
+func TestWithdrawn(t *testing.T) {
+	p := &Product{}
+	require.False(t, p.Withdrawn())
+	p.WithdrawnStatus = int64(StatusWithdrawn)
+	require.True(t, p.Withdrawn())
+
+	m := &Model{}
+	require.False(t, m.Withdrawn())
+	m.WithdrawnStatus = int(StatusWithdrawn)
+	require.True(t, m.Withdrawn())
+	m.WithdrawnStatus = int(StatusActive)
+	m.Product.WithdrawnStatus = int64(StatusWithdrawn)
+	require.True(t, m.Withdrawn())
+
+	require.Equal(t, "withdrawn", StatusWithdrawn.String())
+	require.Equal(t, "WithdrawnStatus(5)", WithdrawnStatus(5).String())
+}
+
+var casesDate = []struct {
+	name string
+	json string
+	exp  Date
+	out  string
+}{
+	{name: "valid", json: `"2022-03-15"`, exp: Date(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)), out: `"2022-03-15"`},
+	{name: "null", json: `null`, out: `null`},
+	{name: "empty", json: `""`, out: `null`},
+}
+
+func TestDateJSON(t *testing.T) {
+	for _, c := range casesDate {
+		t.Run(c.name, func(t *testing.T) {
+			d := Date(time.Now())
+			err := json.Unmarshal([]byte(c.json), &d)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, d)
+
+			data, err := json.Marshal(d)
+			require.NoError(t, err)
+			require.Equal(t, c.out, string(data))
+		})
+	}
+	var d Date
+	require.Error(t, json.Unmarshal([]byte(`"15/03/2022"`), &d))
+
+	var m struct {
+		Updated Date `json:"LastUpdated"`
+		Name    string
+	}
+	err := json.Unmarshal([]byte(`{"LastUpdated":"","Name":"x"}`), &m)
+	require.NoError(t, err)
+	require.Equal(t, "x", m.Name)
+}
+
+func TestDateAccessors(t *testing.T) {
+	tm := time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)
+	d := Date(tm)
+	require.Equal(t, tm, d.Time())
+	require.False(t, d.IsZero())
+	require.Equal(t, "2022-03-15", d.String())
+
+	var z Date
+	require.True(t, z.IsZero())
+	require.Equal(t, "", z.String())
+}
+
+var casesUpdatesVersion = []struct {
+	name  string
+	title string
+	vers  uint64
+	err   bool
+}{
+	{name: "valid", title: "<b>Version 593 - Oct.5, 2022</b>", vers: 593},
+	{name: "empty", title: ""},
+	{name: "no number", title: "<b>Version - Oct.5, 2022</b>", err: true},
+	{name: "garbage", title: "Latest", err: true},
+	{name: "overflow", title: "Version 99999999999999999999999", err: true},
+}
+
+func TestUpdatesVersion(t *testing.T) {
+	for _, c := range casesUpdatesVersion {
+		t.Run(c.name, func(t *testing.T) {
+			upd := &Updates{
+				VersionTitle: c.title,
+				Updated:      []UpdatedProduct{{ID: 1, Title: "X (spec updated)"}},
+			}
+			err := upd.parse()
+			if c.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, c.vers, upd.Version)
+			require.Equal(t, c.vers != 0, upd.HasVersion())
+			require.Equal(t, ReasonSpecUpdated, upd.Updated[0].Reason)
+		})
+	}
+	var upd *Updates
+	require.False(t, upd.HasVersion())
+	require.NoError(t, upd.parse())
+}
+
+var casesVersionTS = []struct {
+	title string
+	exp   time.Time
+}{
+	{title: "Version 593 - Oct.5, 2022", exp: time.Date(2022, 10, 5, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 640 - Sept.12, 2023", exp: time.Date(2023, 9, 12, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 641 - Sep.1, 2023", exp: time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 600 - June.30, 2023", exp: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 601 - May 5, 2023", exp: time.Date(2023, 5, 5, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 602 - Dec. 25, 2023", exp: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 12, 2023 - JAN.02, 2024", exp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	{title: "Version 603"},
+	{title: "Version 604 - Foo.5, 2023"},
+}
+
+func TestUpdatesVersionTS(t *testing.T) {
+	for _, c := range casesVersionTS {
+		upd := &Updates{VersionTitle: c.title}
+		require.NoError(t, upd.parse())
+		require.Equal(t, c.exp, upd.VersionTS, c.title)
+	}
+}
+
+func TestUpdateReasons(t *testing.T) {
+	upd := &Updates{
+		VersionTitle: "Version 1",
+		Updated: []UpdatedProduct{
+			{Title: "A (new model added)"},
+			{Title: "B (spec updated)"},
+			{Title: "C (photo updated)"},
+			{Title: "D"},
+			{Title: "E ()"},
+		},
+	}
+	require.NoError(t, upd.parse())
+	require.Equal(t, []UpdatedProduct{
+		{Title: "A", Reason: ReasonNewModel},
+		{Title: "B", Reason: ReasonSpecUpdated},
+		{Title: "C", Reason: "photo updated"},
+		{Title: "D"},
+		{Title: "E ()"},
+	}, upd.Updated)
+	require.True(t, upd.Updated[0].Reason.Known())
+	require.True(t, upd.Updated[1].Reason.Known())
+	require.False(t, upd.Updated[2].Reason.Known())
+}
+
+func TestValidate(t *testing.T) {
+	p := &Product{ID: 1972, Key: "ThinkPad_X1_Carbon_Gen_10", Models: []ModelInfo{{Code: "21CB000AUS"}}}
+	require.NoError(t, p.Validate())
+
+	err := (&Product{}).Validate()
+	require.EqualError(t, err, "product ID is not set\nproduct key and name are not set\nproduct has no models")
+	require.Error(t, (&Product{Name: "X1"}).Validate())
+
+	m := &Model{Product: Product{ID: 1972}, Code: "21CB000AUS", Detail: []KeyValue{{Name: "Memory", Value: "16GB"}}}
+	require.NoError(t, m.Validate())
+
+	err = (&Model{Code: "21CB000AUS"}).Validate()
+	require.EqualError(t, err, "product ID is not set\nmodel has no specifications")
+}
+
+func TestParseModelCode(t *testing.T) {
+	for _, s := range []string{"21CB000AUS", " 21cb000aus\n", "82AW006JRK", "20XWCTO1WW"} {
+		c, err := ParseModelCode(s)
+		require.NoError(t, err, "%q", s)
+		require.True(t, c.Valid())
+		require.Equal(t, strings.ToUpper(strings.TrimSpace(s)), string(c))
+	}
+	for _, s := range []string{"", "21CB", "21CB-000AUS", "21CB000AUS21CB000AUS", "21CB 000AUS", "ThinkPad X1"} {
+		_, err := ParseModelCode(s)
+		require.Error(t, err, "%q", s)
+	}
+	require.False(t, ModelCode("21cb000aus").Valid())
+}
@@ -0,0 +1,120 @@
+package psref
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxURLCandidates limits the number of search results checked by ProductByURL.
+const maxURLCandidates = 5
+
+// webBaseURL is the base URL of the PSREF website.
+const webBaseURL = "https://psref.lenovo.com"
+
+// ProductWebURL returns the PSREF website URL of a product with a given key (see Product.Key), e.g.
+// "https://psref.lenovo.com/Product/ThinkPad/ThinkPad_X1_Carbon_Gen_10".
+//
+// If the model code is set, the URL references the model, e.g.
+// "https://psref.lenovo.com/Detail/ThinkPad/ThinkPad_X1_Carbon_Gen_10?M=21CB000AUS".
+// It returns an empty string if the key is empty.
+func ProductWebURL(key string, code ModelCode) string {
+	info, err := ParseProductKey(key)
+	if err != nil {
+		return ""
+	}
+	u := &url.URL{Path: "/Product/" + info.Family + "/" + key}
+	if code != "" {
+		u.Path = "/Detail/" + info.Family + "/" + key
+		u.RawQuery = url.Values{"M": {string(code)}}.Encode()
+	}
+	return webBaseURL + u.String()
+}
+
+// parseWebURL extracts the product key and the model code from a PSREF website URL.
+//
+// Both "/Detail/<family>/<key>?M=<code>" and "/Product/<family>/<key>" shapes are supported.
+func parseWebURL(rawURL string) (string, ModelCode, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || (!strings.EqualFold(parts[0], "Detail") && !strings.EqualFold(parts[0], "Product")) {
+		return "", "", fmt.Errorf("unrecognized PSREF URL: %q", rawURL)
+	}
+	key := parts[len(parts)-1]
+	if key == "" {
+		return "", "", fmt.Errorf("unrecognized PSREF URL: %q", rawURL)
+	}
+	return key, ModelCode(strings.TrimSpace(u.Query().Get("M"))), nil
+}
+
+// ProductByURL returns the product referenced by a PSREF website URL, e.g.
+// "https://psref.lenovo.com/Detail/ThinkPad/ThinkPad_X1_Carbon_Gen_10?M=21CB000AUS".
+//
+// If the URL references a specific model with the "M" query parameter, the model is returned as well.
+// Otherwise, the returned model is nil.
+//
+// This method uses the search API, which might be considerably slower. Use ProductByID instead.
+func (c *Client) ProductByURL(ctx context.Context, rawURL string) (*Product, *Model, error) {
+	key, code, err := parseWebURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	var p *Product
+	if code != "" {
+		p, err = c.ProductByModelCode(ctx, code)
+	} else {
+		p, err = c.productByKey(ctx, key)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if code == "" {
+		return p, nil, nil
+	}
+	m, err := c.ModelByID(ctx, p.ID, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, m, nil
+}
+
+// productByKey finds the product with a given key (see Product.Key) using the search API.
+//
+// Search results with a name matching the key are checked first.
+func (c *Client) productByKey(ctx context.Context, key string) (*Product, error) {
+	name := strings.ReplaceAll(key, "_", " ")
+	res, err := c.Search(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var ids []PID
+	for _, r := range res {
+		if strings.EqualFold(r.Name, name) {
+			ids = append(ids, r.ID)
+		}
+	}
+	for _, r := range res {
+		if !strings.EqualFold(r.Name, name) {
+			ids = append(ids, r.ID)
+		}
+	}
+	if len(ids) > maxURLCandidates {
+		ids = ids[:maxURLCandidates]
+	}
+	for _, id := range ids {
+		p, err := c.ProductByID(ctx, id)
+		if err == ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(p.Key, key) {
+			return p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,88 @@
+package psref
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebURL(t *testing.T) {
+	key, code, err := parseWebURL("https://psref.lenovo.com/Detail/ThinkPad/ThinkPad_X1_Carbon_Gen_10?M=21CB000AUS")
+	require.NoError(t, err)
+	require.Equal(t, "ThinkPad_X1_Carbon_Gen_10", key)
+	require.Equal(t, ModelCode("21CB000AUS"), code)
+
+	key, code, err = parseWebURL("https://psref.lenovo.com/Product/Legion/Lenovo_Legion_5P_15IMH05H")
+	require.NoError(t, err)
+	require.Equal(t, "Lenovo_Legion_5P_15IMH05H", key)
+	require.Equal(t, ModelCode(""), code)
+
+	for _, s := range []string{
+		"https://psref.lenovo.com/",
+		"https://psref.lenovo.com/Search/ThinkPad",
+		"https://psref.lenovo.com/Detail/",
+		"%zz",
+	} {
+		_, _, err = parseWebURL(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestProductWebURL(t *testing.T) {
+	u := ProductWebURL("ThinkPad_X1_Carbon_Gen_10", "21CB000AUS")
+	require.Equal(t, "https://psref.lenovo.com/Detail/ThinkPad/ThinkPad_X1_Carbon_Gen_10?M=21CB000AUS", u)
+	key, code, err := parseWebURL(u)
+	require.NoError(t, err)
+	require.Equal(t, "ThinkPad_X1_Carbon_Gen_10", key)
+	require.Equal(t, ModelCode("21CB000AUS"), code)
+
+	require.Equal(t, "https://psref.lenovo.com/Product/Legion/Lenovo_Legion_5P_15IMH05H", ProductWebURL("Lenovo_Legion_5P_15IMH05H", ""))
+	require.Equal(t, "", ProductWebURL("", "21CB000AUS"))
+}
+
+func TestProductAPIURL(t *testing.T) {
+	c := NewClient(WithBaseURL("http://example.com"))
+	require.Equal(t, "http://example.com/psref/mobile/product/1972?api_v="+apiVersion, c.ProductAPIURL(1972))
+}
+
+func TestProductByURL(t *testing.T) {
+	c := newTransportClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/psref/mobile/searchv3":
+			switch req.URL.Query().Get("kw") {
+			case "21CB000AUS":
+				return newResponse(req, http.StatusOK, `{"result": [{"ProductId": 1972, "ProductName": "ThinkPad X1 Carbon Gen 10", "ModelCount": 1}]}`), nil
+			case "ThinkPad X1 Carbon Gen 10":
+				return newResponse(req, http.StatusOK, `{"result": [
+					{"ProductId": 1973, "ProductName": "ThinkPad X1 Carbon Gen 10 (Intel)"},
+					{"ProductId": 1972, "ProductName": "ThinkPad X1 Carbon Gen 10"}
+				]}`), nil
+			}
+			return newResponse(req, http.StatusOK, `{"result": []}`), nil
+		case "/psref/mobile/product/1972":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972, "ProductKey": "ThinkPad_X1_Carbon_Gen_10"}`), nil
+		case "/psref/mobile/product/1973":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1973, "ProductKey": "ThinkPad_X1_Carbon_Gen_10_Intel"}`), nil
+		case "/psref/mobile/Model/1972/21CB000AUS":
+			return newResponse(req, http.StatusOK, `{"ProductId": 1972}`), nil
+		}
+		return newResponse(req, http.StatusNotFound, ""), nil
+	})
+	ctx := context.Background()
+
+	p, m, err := c.ProductByURL(ctx, "https://psref.lenovo.com/Detail/ThinkPad/ThinkPad_X1_Carbon_Gen_10?M=21CB000AUS")
+	require.NoError(t, err)
+	require.Equal(t, PID(1972), p.ID)
+	require.NotNil(t, m)
+	require.Equal(t, ModelCode("21CB000AUS"), m.Code)
+
+	p, m, err = c.ProductByURL(ctx, "https://psref.lenovo.com/Product/ThinkPad/ThinkPad_X1_Carbon_Gen_10")
+	require.NoError(t, err)
+	require.Equal(t, PID(1972), p.ID)
+	require.Nil(t, m)
+
+	_, _, err = c.ProductByURL(ctx, "https://psref.lenovo.com/Product/ThinkPad/ThinkPad_X13_Gen_1")
+	require.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,112 @@
+package psref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WWANSupport describes whether the model has a cellular modem. See WirelessSpec.
+type WWANSupport int
+
+const (
+	// WWANNone is set for models without a cellular modem.
+	WWANNone = WWANSupport(0)
+	// WWANOptional is set for models where the cellular modem can be configured, but is not included.
+	WWANOptional = WWANSupport(1)
+	// WWANIncluded is set for models with a cellular modem.
+	WWANIncluded = WWANSupport(2)
+)
+
+func (s WWANSupport) String() string {
+	switch s {
+	case WWANNone:
+		return "none"
+	case WWANOptional:
+		return "optional"
+	case WWANIncluded:
+		return "included"
+	}
+	return "wwan(" + strconv.Itoa(int(s)) + ")"
+}
+
+// WirelessSpec is a parsed wireless connectivity specification. See Model.Wireless.
+//
+// Fields that cannot be determined from the specification are left empty.
+type WirelessSpec struct {
+	RawWLAN string
+	RawWWAN string
+
+	WiFiStandard     string // e.g. "WiFi 6", "WiFi 6E", "WiFi 7"
+	BluetoothVersion string // e.g. "5.2"
+
+	WWAN           WWANSupport
+	WWANGeneration string // "4G" or "5G"
+}
+
+var (
+	reWiFiVersion = regexp.MustCompile(`(?i)\bWi-?Fi\s*(\d+E?)\b`)
+	reWiFi80211   = regexp.MustCompile(`(?i)\b802\.11\s*([a-z]{1,2})\b`)
+	reBluetooth   = regexp.MustCompile(`(?i)\b(?:BT|Bluetooth)\s*(\d+(?:\.\d+)?)`)
+	reWWAN5G      = regexp.MustCompile(`(?i)\b5G\b`)
+	reWWAN4G      = regexp.MustCompile(`(?i)\b(?:4G|LTE)\b`)
+)
+
+// wifi80211 maps IEEE 802.11 amendments to WiFi generations.
+var wifi80211 = map[string]string{
+	"n":  "WiFi 4",
+	"ac": "WiFi 5",
+	"ax": "WiFi 6",
+	"be": "WiFi 7",
+}
+
+// Wireless parses the "WLAN + Bluetooth" and "WWAN" specifications of the model.
+//
+// Partially recognized specifications are not considered an error. An error is returned only
+// when the model has neither WLAN, nor WWAN specification.
+func (m *Model) Wireless() (*WirelessSpec, error) {
+	w := &WirelessSpec{}
+	for _, v := range m.Detail {
+		if w.RawWLAN == "" && strings.HasPrefix(v.Name, "WLAN") {
+			w.RawWLAN = strings.TrimSpace(v.Value)
+		}
+	}
+	w.RawWWAN = strings.TrimSpace(m.DetailByName("WWAN"))
+	if w.RawWLAN == "" && w.RawWWAN == "" {
+		return nil, fmt.Errorf("detail %q: %w", "WLAN + Bluetooth", ErrNotFound)
+	}
+	w.parseWLAN(w.RawWLAN)
+	w.parseWWAN(w.RawWWAN)
+	return w, nil
+}
+
+func (w *WirelessSpec) parseWLAN(s string) {
+	if sub := reWiFiVersion.FindStringSubmatch(s); sub != nil {
+		w.WiFiStandard = "WiFi " + strings.ToUpper(sub[1])
+	} else if sub := reWiFi80211.FindStringSubmatch(s); sub != nil {
+		w.WiFiStandard = wifi80211[strings.ToLower(sub[1])]
+	}
+	if sub := reBluetooth.FindStringSubmatch(s); sub != nil {
+		w.BluetoothVersion = sub[1]
+	}
+}
+
+func (w *WirelessSpec) parseWWAN(s string) {
+	lower := strings.ToLower(s)
+	switch {
+	case s == "", lower == "none", strings.HasPrefix(lower, "no "):
+		w.WWAN = WWANNone
+		return
+	case strings.Contains(lower, "optional"):
+		w.WWAN = WWANOptional
+	default:
+		w.WWAN = WWANIncluded
+	}
+	switch {
+	case reWWAN5G.MatchString(s):
+		w.WWANGeneration = "5G"
+	case reWWAN4G.MatchString(s):
+		w.WWANGeneration = "4G"
+	}
+}
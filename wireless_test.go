@@ -0,0 +1,72 @@
+package psref
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesWireless = []struct {
+	name string
+	wlan string
+	wwan string
+	exp  WirelessSpec
+}{
+	{
+		name: "wifi 6e",
+		wlan: "Intel Wi-Fi 6E AX211, 802.11ax 2x2 + BT5.2",
+		wwan: "None",
+		exp:  WirelessSpec{WiFiStandard: "WiFi 6E", BluetoothVersion: "5.2"},
+	},
+	{
+		name: "802.11",
+		wlan: "Realtek 802.11ac, 2x2 + Bluetooth 5.0",
+		exp:  WirelessSpec{WiFiStandard: "WiFi 5", BluetoothVersion: "5.0"},
+	},
+	{
+		name: "5g",
+		wlan: "Qualcomm Wi-Fi 7 WCN7850, 802.11be 2x2 + BT5.3",
+		wwan: "Quectel RM520N-GL, 5G Sub-6 GHz",
+		exp:  WirelessSpec{WiFiStandard: "WiFi 7", BluetoothVersion: "5.3", WWAN: WWANIncluded, WWANGeneration: "5G"},
+	},
+	{
+		name: "optional",
+		wlan: "Wi-Fi 6, 802.11ax 2x2 + BT5.1",
+		wwan: "Optional 4G LTE CAT16",
+		exp:  WirelessSpec{WiFiStandard: "WiFi 6", BluetoothVersion: "5.1", WWAN: WWANOptional, WWANGeneration: "4G"},
+	},
+	{
+		name: "wwan only",
+		wwan: "Fibocom L860-GL, 4G LTE",
+		exp:  WirelessSpec{WWAN: WWANIncluded, WWANGeneration: "4G"},
+	},
+	{
+		name: "unknown",
+		wlan: "Some card",
+		exp:  WirelessSpec{},
+	},
+}
+
+func TestWireless(t *testing.T) {
+	for _, c := range casesWireless {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := &Model{}
+			if c.wlan != "" {
+				m.Detail = append(m.Detail, KeyValue{Name: "WLAN + Bluetooth", Value: c.wlan})
+			}
+			if c.wwan != "" {
+				m.Detail = append(m.Detail, KeyValue{Name: "WWAN", Value: c.wwan})
+			}
+			got, err := m.Wireless()
+			require.NoError(t, err)
+			exp := c.exp
+			exp.RawWLAN, exp.RawWWAN = c.wlan, c.wwan
+			require.Equal(t, &exp, got)
+		})
+	}
+	_, err := (&Model{}).Wireless()
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.Equal(t, "optional", WWANOptional.String())
+}